@@ -1,10 +1,22 @@
+resource "netbox_extras_tag" "tag1" {
+  name = "tag1"
+  slug = "tag1"
+  color = "2196f3"
+}
+
+resource "netbox_extras_tag" "tag3" {
+  name = "tag3"
+  slug = "tag3"
+  description = "Tag created by terraform"
+}
+
 resource "netbox_tenancy_tenant" "tenant_test" {
   name            = "Test_Tenant"
   slug            = "Test_Tenant"
   description     = "Tenant created by terraform"
   comments        = "Some test comments"
   tenant_group_id = netbox_tenancy_tenant_group.tenant_group_test.id
-  tags            = ["tag1", "tag3"]
+  tags            = [netbox_extras_tag.tag1.slug, netbox_extras_tag.tag3.slug]
 }
 
 resource "netbox_tenancy_tenant_group" "tenant_group_test" {
@@ -22,11 +34,13 @@ resource "netbox_ipam_vlan_group" "vlan_group_test" {
   site_id = data.netbox_dcim_site.site_test.id
 }
 
-data "netbox_ipam_role" "vlan_role_production" {
+resource "netbox_ipam_role" "vlan_role_production" {
+  name = "Production"
   slug = "production"
 }
 
-data "netbox_ipam_role" "vlan_role_backup" {
+resource "netbox_ipam_role" "vlan_role_backup" {
+  name = "Backup"
   slug = "backup"
 }
 
@@ -37,8 +51,8 @@ resource "netbox_ipam_vlan" "vlan_test" {
   description = "VLAN created by terraform"
   vlan_group_id = netbox_ipam_vlan_group.vlan_group_test.id
   tenant_id = netbox_tenancy_tenant.tenant_test.id
-  role_id = data.netbox_ipam_role.vlan_role_production.id
-  tags = ["tag1"]
+  role_id = netbox_ipam_role.vlan_role_production.id
+  tags = [netbox_extras_tag.tag1.slug]
 }
 
 resource "netbox_ipam_prefix" "prefix_test" {
@@ -46,8 +60,8 @@ resource "netbox_ipam_prefix" "prefix_test" {
   vlan_id = netbox_ipam_vlan.vlan_test.id
   description = "Prefix created by terraform"
   site_id = netbox_ipam_vlan_group.vlan_group_test.site_id
-  role_id = data.netbox_ipam_role.vlan_role_production.id
-  tags = ["tag1"]
+  role_id = netbox_ipam_role.vlan_role_production.id
+  tags = [netbox_extras_tag.tag1.slug]
   status = "container"
 }
 
@@ -5,6 +5,7 @@ go 1.14
 require (
 	github.com/go-openapi/runtime v0.19.21
 	github.com/go-openapi/strfmt v0.19.5
+	github.com/go-openapi/swag v0.19.9
 	github.com/hashicorp/terraform-plugin-sdk v1.13.0
 	github.com/pkg/errors v0.9.1
 	github.com/tomasherout/go-netbox v0.0.0-20201013062410-ef6300cf142c
@@ -0,0 +1,217 @@
+package netbox
+
+import (
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/tomasherout/go-netbox/netbox/client/ipam"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+const cacheIpamIPAddresses = "ipam_ip_addresses"
+const cacheIpamPrefixes = "ipam_prefixes"
+const cacheIpamVlans = "ipam_vlans"
+
+// objectCache keeps in memory all the objects of a type, listed at once on
+// the first Read of the type. The Reads of a refresh are then answered
+// without a query per resource.
+//
+// Objects missing from the listing, e.g. created afterwards, and objects
+// written by the provider since are not answered by the cache, the caller
+// retrieves them from Netbox.
+//
+// The provider does not know which objects the plan holds, the whole table is
+// listed instead of the objects of the plan only (e.g. with id__in). This
+// pays off when the plan holds a large share of the table, with a few
+// resources in a large Netbox the listing costs more than their reads.
+type objectCache struct {
+	ttl   time.Duration
+	mutex sync.Mutex
+	kinds map[string]*objectCacheKind
+}
+
+type objectCacheKind struct {
+	// held while the objects are listed, so concurrent Reads wait for the
+	// listing instead of listing as well
+	mutex   sync.Mutex
+	loaded  time.Time
+	objects map[int64]interface{}
+}
+
+func newObjectCache(ttl time.Duration) *objectCache {
+	return &objectCache{
+		ttl:   ttl,
+		kinds: make(map[string]*objectCacheKind),
+	}
+}
+
+func (c *objectCache) kind(name string) *objectCacheKind {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	kind, ok := c.kinds[name]
+	if !ok {
+		kind = &objectCacheKind{}
+		c.kinds[name] = kind
+	}
+
+	return kind
+}
+
+// get returns the object of the given kind and id, listing all the objects
+// of the kind when the cache is empty or expired. ok is false when the cache
+// is disabled or does not hold the object.
+func (c *objectCache) get(name string, id int64,
+	list func() (map[int64]interface{}, error)) (interface{}, bool, error) {
+	if c == nil || c.ttl <= 0 {
+		return nil, false, nil
+	}
+
+	kind := c.kind(name)
+	kind.mutex.Lock()
+	defer kind.mutex.Unlock()
+
+	if kind.objects == nil || time.Since(kind.loaded) > c.ttl {
+		objects, err := list()
+		if err != nil {
+			return nil, false, err
+		}
+
+		kind.objects = objects
+		kind.loaded = time.Now()
+	}
+
+	object, ok := kind.objects[id]
+	return object, ok, nil
+}
+
+// forget drops objects written by the provider, the next Reads retrieve them
+// from Netbox.
+func (c *objectCache) forget(name string, ids ...int64) {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+
+	kind := c.kind(name)
+	kind.mutex.Lock()
+	defer kind.mutex.Unlock()
+
+	for _, id := range ids {
+		delete(kind.objects, id)
+	}
+}
+
+// ipamIPAddressGet returns the IP address with the given ID, nil when it
+// does not exist.
+func ipamIPAddressGet(client *providerClient,
+	id int64) (*models.IPAddress, error) {
+	cached, ok, err := client.cache.get(cacheIpamIPAddresses, id,
+		func() (map[int64]interface{}, error) {
+			list, err := ipamIPAddressesListAll(client,
+				ipam.NewIpamIPAddressesListParams())
+			if err != nil {
+				return nil, err
+			}
+
+			objects := make(map[int64]interface{}, len(list))
+			for _, ip := range list {
+				objects[ip.ID] = ip
+			}
+
+			return objects, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		return cached.(*models.IPAddress), nil
+	}
+
+	params := ipam.NewIpamIPAddressesReadParams().WithID(id)
+	response, err := client.Ipam.IpamIPAddressesRead(params, nil)
+	if err != nil {
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return response.Payload, nil
+}
+
+// ipamPrefixGet returns the prefix with the given ID, nil when it does not
+// exist.
+func ipamPrefixGet(client *providerClient, id int64) (*models.Prefix, error) {
+	cached, ok, err := client.cache.get(cacheIpamPrefixes, id,
+		func() (map[int64]interface{}, error) {
+			list, err := ipamPrefixesListAll(client,
+				ipam.NewIpamPrefixesListParams())
+			if err != nil {
+				return nil, err
+			}
+
+			objects := make(map[int64]interface{}, len(list))
+			for _, prefix := range list {
+				objects[prefix.ID] = prefix
+			}
+
+			return objects, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		return cached.(*models.Prefix), nil
+	}
+
+	params := ipam.NewIpamPrefixesReadParams().WithID(id)
+	response, err := client.Ipam.IpamPrefixesRead(params, nil)
+	if err != nil {
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return response.Payload, nil
+}
+
+// ipamVlanGet returns the vlan with the given ID, nil when it does not
+// exist.
+func ipamVlanGet(client *providerClient, id int64) (*models.VLAN, error) {
+	cached, ok, err := client.cache.get(cacheIpamVlans, id,
+		func() (map[int64]interface{}, error) {
+			list, err := ipamVlansListAll(client, ipam.NewIpamVlansListParams())
+			if err != nil {
+				return nil, err
+			}
+
+			objects := make(map[int64]interface{}, len(list))
+			for _, vlan := range list {
+				objects[vlan.ID] = vlan
+			}
+
+			return objects, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		return cached.(*models.VLAN), nil
+	}
+
+	params := ipam.NewIpamVlansReadParams().WithID(id)
+	response, err := client.Ipam.IpamVlansRead(params, nil)
+	if err != nil {
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return response.Payload, nil
+}
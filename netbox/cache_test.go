@@ -0,0 +1,87 @@
+package netbox
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+func TestObjectCache(t *testing.T) {
+	cache := newObjectCache(time.Minute)
+
+	listed := 0
+	list := func() (map[int64]interface{}, error) {
+		listed++
+		return map[int64]interface{}{1: "one", 2: "two"}, nil
+	}
+
+	for _, id := range []int64{1, 2, 1} {
+		if _, ok, err := cache.get("kind", id, list); err != nil || !ok {
+			t.Fatalf("object %d: got %t, %v", id, ok, err)
+		}
+	}
+
+	if listed != 1 {
+		t.Errorf("listed %d times, want 1", listed)
+	}
+
+	// written or deleted by the provider
+	cache.forget("kind", 1)
+
+	if _, ok, _ := cache.get("kind", 1, list); ok {
+		t.Error("a forgotten object is answered by the cache")
+	}
+
+	if _, ok, _ := cache.get("kind", 2, list); !ok {
+		t.Error("forgetting an object dropped the others")
+	}
+
+	if _, ok, _ := cache.get("kind", 3, list); ok {
+		t.Error("an object missing from the listing is answered by the cache")
+	}
+}
+
+func TestObjectCacheDisabled(t *testing.T) {
+	var cache *objectCache
+
+	_, ok, err := cache.get("kind", 1, func() (map[int64]interface{},
+		error) {
+		t.Fatal("the disabled cache lists the objects")
+		return nil, nil
+	})
+	if err != nil || ok {
+		t.Errorf("got %t, %v", ok, err)
+	}
+
+	cache.forget("kind", 1)
+}
+
+func TestIpamIPAddressesDeleteForgetsCache(t *testing.T) {
+	client, done := newTestClient(func(w http.ResponseWriter,
+		r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer done()
+	client.cache = newObjectCache(time.Minute)
+
+	_, _, err := client.cache.get(cacheIpamIPAddresses, 1,
+		func() (map[int64]interface{}, error) {
+			return map[int64]interface{}{1: &models.IPAddress{ID: 1}}, nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := resourceNetboxIpamIPAddresses().TestResourceData()
+	d.SetId("1")
+
+	if err := resourceNetboxIpamIPAddressesDelete(d, client); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := client.cache.kind(cacheIpamIPAddresses).objects[1]; ok {
+		t.Error("the deleted address is still answered by the cache")
+	}
+}
@@ -0,0 +1,59 @@
+package netbox
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/circuits"
+)
+
+func dataNetboxCircuitsCircuit() *schema.Resource {
+	return &schema.Resource{
+		Read: dataNetboxCircuitsCircuitRead,
+
+		Schema: dataSourceSchema(circuitsCircuitAttributes(),
+			map[string]*schema.Schema{
+				"cid": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringLenBetween(1, 50),
+				},
+				"provider_id": {
+					Type:     schema.TypeInt,
+					Optional: true,
+					Computed: true,
+				},
+			}),
+	}
+}
+
+func dataNetboxCircuitsCircuitRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	cid := d.Get("cid").(string)
+	providerID := int64(d.Get("provider_id").(int))
+	providerIDStr := strconv.FormatInt(providerID, 10)
+
+	// the cid is only unique per provider
+	p := circuits.NewCircuitsCircuitsListParams().WithCid(&cid)
+	if providerID != 0 {
+		p.SetProviderID(&providerIDStr)
+	}
+
+	list, err := circuitsCircuitsListAll(client, p)
+	if err != nil {
+		return err
+	}
+
+	if len(list) == 1 {
+		d.SetId(strconv.FormatInt(list[0].ID, 10))
+	} else {
+		return pkgerrors.New("Data results for netbox_circuits_circuit returns " +
+			"0 or more than one result.")
+	}
+
+	return setDataSourceAttributes(d, flattenCircuitsCircuit(list[0]))
+}
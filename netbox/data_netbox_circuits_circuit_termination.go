@@ -0,0 +1,57 @@
+package netbox
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/circuits"
+)
+
+func dataNetboxCircuitsCircuitTermination() *schema.Resource {
+	return &schema.Resource{
+		Read: dataNetboxCircuitsCircuitTerminationRead,
+
+		Schema: dataSourceSchema(circuitsCircuitTerminationAttributes(),
+			map[string]*schema.Schema{
+				"circuit_id": {
+					Type:     schema.TypeInt,
+					Required: true,
+				},
+				"term_side": {
+					Type:     schema.TypeString,
+					Required: true,
+					ValidateFunc: validation.StringInSlice([]string{"A", "Z"},
+						false),
+				},
+			}),
+	}
+}
+
+func dataNetboxCircuitsCircuitTerminationRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	circuitID := strconv.FormatInt(int64(d.Get("circuit_id").(int)), 10)
+	termSide := d.Get("term_side").(string)
+
+	p := circuits.NewCircuitsCircuitTerminationsListParams().WithCircuitID(
+		&circuitID).WithTermSide(&termSide)
+
+	list, err := circuitsCircuitTerminationsListAll(client, p)
+	if err != nil {
+		return err
+	}
+
+	if len(list) == 1 {
+		d.SetId(strconv.FormatInt(list[0].ID, 10))
+	} else {
+		return pkgerrors.New("Data results for " +
+			"netbox_circuits_circuit_termination returns 0 or more than one " +
+			"result.")
+	}
+
+	return setDataSourceAttributes(d,
+		flattenCircuitsCircuitTermination(list[0]))
+}
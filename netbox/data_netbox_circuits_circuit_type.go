@@ -0,0 +1,51 @@
+package netbox
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/circuits"
+)
+
+func dataNetboxCircuitsCircuitType() *schema.Resource {
+	return &schema.Resource{
+		Read: dataNetboxCircuitsCircuitTypeRead,
+
+		Schema: dataSourceSchema(circuitsCircuitTypeAttributes(),
+			map[string]*schema.Schema{
+				"slug": {
+					Type:     schema.TypeString,
+					Required: true,
+					ValidateFunc: validation.StringMatch(
+						regexp.MustCompile("^[-a-zA-Z0-9_]{1,50}$"),
+						"Must be like ^[-a-zA-Z0-9_]{1,50}$"),
+				},
+			}),
+	}
+}
+
+func dataNetboxCircuitsCircuitTypeRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	slug := d.Get("slug").(string)
+
+	p := circuits.NewCircuitsCircuitTypesListParams().WithSlug(&slug)
+
+	list, err := circuitsCircuitTypesListAll(client, p)
+	if err != nil {
+		return err
+	}
+
+	if len(list) == 1 {
+		d.SetId(strconv.FormatInt(list[0].ID, 10))
+	} else {
+		return pkgerrors.New("Data results for netbox_circuits_circuit_type returns " +
+			"0 or more than one result.")
+	}
+
+	return setDataSourceAttributes(d, flattenCircuitsCircuitType(list[0]))
+}
@@ -0,0 +1,51 @@
+package netbox
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/circuits"
+)
+
+func dataNetboxCircuitsProvider() *schema.Resource {
+	return &schema.Resource{
+		Read: dataNetboxCircuitsProviderRead,
+
+		Schema: dataSourceSchema(circuitsProviderAttributes(),
+			map[string]*schema.Schema{
+				"slug": {
+					Type:     schema.TypeString,
+					Required: true,
+					ValidateFunc: validation.StringMatch(
+						regexp.MustCompile("^[-a-zA-Z0-9_]{1,50}$"),
+						"Must be like ^[-a-zA-Z0-9_]{1,50}$"),
+				},
+			}),
+	}
+}
+
+func dataNetboxCircuitsProviderRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	slug := d.Get("slug").(string)
+
+	p := circuits.NewCircuitsProvidersListParams().WithSlug(&slug)
+
+	list, err := circuitsProvidersListAll(client, p)
+	if err != nil {
+		return err
+	}
+
+	if len(list) == 1 {
+		d.SetId(strconv.FormatInt(list[0].ID, 10))
+	} else {
+		return pkgerrors.New("Data results for netbox_circuits_provider returns 0 " +
+			"or more than one result.")
+	}
+
+	return setDataSourceAttributes(d, flattenCircuitsProvider(list[0]))
+}
@@ -0,0 +1,125 @@
+package netbox
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// configContextHost is the part of a device or a virtual machine holding its
+// rendered config context, the generated models do not export it.
+type configContextHost struct {
+	ID            int64           `json:"id"`
+	ConfigContext json.RawMessage `json:"config_context"`
+}
+
+func dataNetboxConfigContext() *schema.Resource {
+	return &schema.Resource{
+		Read: dataNetboxConfigContextRead,
+
+		Schema: map[string]*schema.Schema{
+			"config_context": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"config_context_flat": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"device_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ExactlyOneOf: []string{"device_id", "virtual_machine_id"},
+			},
+			"virtual_machine_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ExactlyOneOf: []string{"device_id", "virtual_machine_id"},
+			},
+		},
+	}
+}
+
+func dataNetboxConfigContextRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	deviceID := int64(d.Get("device_id").(int))
+	virtualMachineID := int64(d.Get("virtual_machine_id").(int))
+
+	var host configContextHost
+	var err error
+	if deviceID != 0 {
+		err = netboxSubmit(client, "dcim_devices_read", "GET",
+			"/dcim/devices/{id}/", deviceID, nil, &host)
+	} else {
+		err = netboxSubmit(client, "virtualization_virtual-machines_read", "GET",
+			"/virtualization/virtual-machines/{id}/", virtualMachineID, nil,
+			&host)
+	}
+	if err != nil {
+		return err
+	}
+
+	// no config context applies to the host
+	if len(host.ConfigContext) == 0 || string(host.ConfigContext) == "null" {
+		host.ConfigContext = json.RawMessage("{}")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(host.ConfigContext))
+	decoder.UseNumber()
+
+	var configContext interface{}
+	if err = decoder.Decode(&configContext); err != nil {
+		return err
+	}
+
+	flat := make(map[string]string)
+	flattenConfigContext(flat, "", configContext)
+
+	d.SetId(strconv.FormatInt(host.ID, 10))
+
+	if err = d.Set("config_context",
+		normalizeJSON(string(host.ConfigContext))); err != nil {
+		return err
+	}
+
+	return d.Set("config_context_flat", flat)
+}
+
+// flattenConfigContext adds the scalar values of a config context to flat,
+// keyed by their path: object keys and list indexes joined by dots, e.g.
+// ntp.servers.0. Null values are empty strings.
+func flattenConfigContext(flat map[string]string, prefix string,
+	value interface{}) {
+	key := func(k string) string {
+		if prefix == "" {
+			return k
+		}
+		return prefix + "." + k
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			flattenConfigContext(flat, key(k), child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			flattenConfigContext(flat, key(strconv.Itoa(i)), child)
+		}
+	case string:
+		flat[prefix] = v
+	case json.Number:
+		flat[prefix] = v.String()
+	case bool:
+		flat[prefix] = strconv.FormatBool(v)
+	case nil:
+		flat[prefix] = ""
+	}
+}
@@ -0,0 +1,105 @@
+package netbox
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/tomasherout/go-netbox/netbox/client/dcim"
+)
+
+func dataNetboxDcimSites() *schema.Resource {
+	return &schema.Resource{
+		Read: dataNetboxDcimSitesRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"order": orderSchema(),
+			"q": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"region_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"sites": dataSourceListSchema(dcimSiteAttributes()),
+			"slug": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"sort_by": sortBySchema(dcimSiteAttributes()),
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{"planned", "staging",
+					"active", "decommissioning", "retired"}, false),
+			},
+			"tag": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func dataNetboxDcimSitesRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerClient)
+
+	params := dcim.NewDcimSitesListParams()
+
+	if name, ok := d.GetOk("name"); ok {
+		nameStr := name.(string)
+		params.SetName(&nameStr)
+	}
+
+	if q, ok := d.GetOk("q"); ok {
+		qStr := q.(string)
+		params.SetQ(&qStr)
+	}
+
+	if regionID, ok := d.GetOk("region_id"); ok {
+		regionIDStr := strconv.Itoa(regionID.(int))
+		params.SetRegionID(&regionIDStr)
+	}
+
+	if slug, ok := d.GetOk("slug"); ok {
+		slugStr := slug.(string)
+		params.SetSlug(&slugStr)
+	}
+
+	if status, ok := d.GetOk("status"); ok {
+		statusStr := status.(string)
+		params.SetStatus(&statusStr)
+	}
+
+	if tag, ok := d.GetOk("tag"); ok {
+		tagStr := tag.(string)
+		params.SetTag(&tagStr)
+	}
+
+	if tenantID, ok := d.GetOk("tenant_id"); ok {
+		tenantIDStr := strconv.Itoa(tenantID.(int))
+		params.SetTenantID(&tenantIDStr)
+	}
+
+	list, err := dcimSitesListAll(client, params)
+	if err != nil {
+		return err
+	}
+
+	sites := make([]map[string]interface{}, len(list))
+	for i, site := range list {
+		sites[i] = flattenDcimSite(site)
+	}
+
+	return dataSourceListSetResults(d, dataNetboxDcimSites().Schema,
+		"sites", sites)
+}
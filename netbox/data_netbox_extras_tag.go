@@ -0,0 +1,49 @@
+package netbox
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/extras"
+)
+
+func dataNetboxExtrasTag() *schema.Resource {
+	return &schema.Resource{
+		Read: dataNetboxExtrasTagRead,
+
+		Schema: dataSourceSchema(extrasTagAttributes(), map[string]*schema.Schema{
+			"slug": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile("^[-a-zA-Z0-9_]{1,100}$"),
+					"Must be like ^[-a-zA-Z0-9_]{1,100}$"),
+			},
+		}),
+	}
+}
+
+func dataNetboxExtrasTagRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerClient)
+
+	slug := d.Get("slug").(string)
+
+	p := extras.NewExtrasTagsListParams().WithSlug(&slug)
+
+	list, err := extrasTagsListAll(client, p)
+	if err != nil {
+		return err
+	}
+
+	if len(list) == 1 {
+		d.SetId(strconv.FormatInt(list[0].ID, 10))
+	} else {
+		return pkgerrors.New("Data results for netbox_extras_tag returns 0 or " +
+			"more than one result.")
+	}
+
+	return setDataSourceAttributes(d, flattenExtrasTag(list[0]))
+}
@@ -0,0 +1,60 @@
+package netbox
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataNetboxIpamAvailableIPs() *schema.Resource {
+	return &schema.Resource{
+		Read: dataNetboxIpamAvailableIPsRead,
+
+		Schema: map[string]*schema.Schema{
+			"addresses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      50,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"prefix_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+		},
+	}
+}
+
+func dataNetboxIpamAvailableIPsRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	prefixID := int64(d.Get("prefix_id").(int))
+	limit := int64(d.Get("limit").(int))
+
+	list, err := ipamPrefixesAvailableIpsList(client, prefixID, limit)
+	if err != nil {
+		return err
+	}
+
+	addresses := make([]string, len(list))
+	for i, ip := range list {
+		addresses[i] = ip.Address
+	}
+
+	if err = d.Set("addresses", addresses); err != nil {
+		return err
+	}
+
+	d.SetId(strconv.FormatInt(prefixID, 10))
+
+	return nil
+}
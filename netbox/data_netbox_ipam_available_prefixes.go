@@ -0,0 +1,75 @@
+package netbox
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/tomasherout/go-netbox/netbox/client/ipam"
+)
+
+func dataNetboxIpamAvailablePrefixes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataNetboxIpamAvailablePrefixesRead,
+
+		Schema: map[string]*schema.Schema{
+			"prefix_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"prefix_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 128),
+			},
+			"prefixes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataNetboxIpamAvailablePrefixesRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	prefixID := int64(d.Get("prefix_id").(int))
+	prefixLength := d.Get("prefix_length").(int)
+
+	params := ipam.NewIpamPrefixesAvailablePrefixesReadParams().WithID(
+		prefixID)
+	list, err := client.Ipam.IpamPrefixesAvailablePrefixesRead(params, nil)
+	if err != nil {
+		return err
+	}
+
+	var prefixes []string
+	for _, available := range list.Payload {
+		if prefixLength != 0 {
+			_, network, err := net.ParseCIDR(available.Prefix)
+			if err != nil {
+				return err
+			}
+
+			// only the free blocks able to hold a prefix of the length
+			if ones, _ := network.Mask.Size(); ones > prefixLength {
+				continue
+			}
+		}
+
+		prefixes = append(prefixes, available.Prefix)
+	}
+
+	if err = d.Set("prefixes", prefixes); err != nil {
+		return err
+	}
+
+	d.SetId(strconv.FormatInt(prefixID, 10))
+
+	return nil
+}
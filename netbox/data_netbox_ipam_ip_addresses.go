@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	pkgerrors "github.com/pkg/errors"
-	netboxclient "github.com/tomasherout/go-netbox/netbox/client"
 	"github.com/tomasherout/go-netbox/netbox/client/ipam"
 )
 
@@ -15,7 +14,7 @@ func dataNetboxIpamIPAddresses() *schema.Resource {
 	return &schema.Resource{
 		Read: dataNetboxIpamIPAddressesRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: dataSourceSchema(ipamIPAddressAttributes(), map[string]*schema.Schema{
 			"address": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -23,29 +22,29 @@ func dataNetboxIpamIPAddresses() *schema.Resource {
 					regexp.MustCompile("^[0-9]{1,3}.[0-9]{1,3}.[0-9]{1,3}.[0-9]{1,3}/"+
 						"[0-9]{1,2}$"), "Must be like 192.168.56.1/24"),
 			},
-		},
+		}),
 	}
 }
 
 func dataNetboxIpamIPAddressesRead(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
 	address := d.Get("address").(string)
 
 	p := ipam.NewIpamIPAddressesListParams().WithAddress(&address)
 
-	list, err := client.Ipam.IpamIPAddressesList(p, nil)
+	list, err := ipamIPAddressesListAll(client, p)
 	if err != nil {
 		return err
 	}
 
-	if *list.Payload.Count == 1 {
-		d.SetId(strconv.FormatInt(list.Payload.Results[0].ID, 10))
+	if len(list) == 1 {
+		d.SetId(strconv.FormatInt(list[0].ID, 10))
 	} else {
 		return pkgerrors.New("Data results for netbox_ipam_ip_addresses returns 0 or " +
 			"more than one result.")
 	}
 
-	return nil
+	return setDataSourceAttributes(d, flattenIpamIPAddress(list[0]))
 }
@@ -0,0 +1,129 @@
+package netbox
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/tomasherout/go-netbox/netbox/client/ipam"
+)
+
+func dataNetboxIpamIPAddressesList() *schema.Resource {
+	return &schema.Resource{
+		Read: dataNetboxIpamIPAddressesListRead,
+
+		Schema: map[string]*schema.Schema{
+			"dns_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"family": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntInSlice([]int{4, 6}),
+			},
+			"ip_addresses": dataSourceListSchema(ipamIPAddressAttributes()),
+			"order":        orderSchema(),
+			"parent": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsCIDRNetwork(0, 128),
+			},
+			"q": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{"loopback",
+					"secondary", "anycast", "vip", "vrrp", "hsrp", "glbp", "carp"},
+					false),
+			},
+			"sort_by": sortBySchema(ipamIPAddressAttributes()),
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{"active", "reserved",
+					"deprecated", "dhcp"}, false),
+			},
+			"tag": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"vrf_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func dataNetboxIpamIPAddressesListRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	params := ipam.NewIpamIPAddressesListParams()
+
+	if dnsName, ok := d.GetOk("dns_name"); ok {
+		dnsNameStr := dnsName.(string)
+		params.SetDNSName(&dnsNameStr)
+	}
+
+	if family, ok := d.GetOk("family"); ok {
+		familyFloat := float64(family.(int))
+		params.SetFamily(&familyFloat)
+	}
+
+	if parent, ok := d.GetOk("parent"); ok {
+		parentStr := parent.(string)
+		params.SetParent(&parentStr)
+	}
+
+	if q, ok := d.GetOk("q"); ok {
+		qStr := q.(string)
+		params.SetQ(&qStr)
+	}
+
+	if role, ok := d.GetOk("role"); ok {
+		roleStr := role.(string)
+		params.SetRole(&roleStr)
+	}
+
+	if status, ok := d.GetOk("status"); ok {
+		statusStr := status.(string)
+		params.SetStatus(&statusStr)
+	}
+
+	if tag, ok := d.GetOk("tag"); ok {
+		tagStr := tag.(string)
+		params.SetTag(&tagStr)
+	}
+
+	if tenantID, ok := d.GetOk("tenant_id"); ok {
+		tenantIDStr := strconv.Itoa(tenantID.(int))
+		params.SetTenantID(&tenantIDStr)
+	}
+
+	if vrfID, ok := d.GetOk("vrf_id"); ok {
+		vrfIDStr := strconv.Itoa(vrfID.(int))
+		params.SetVrfID(&vrfIDStr)
+	}
+
+	list, err := ipamIPAddressesListAll(client, params)
+	if err != nil {
+		return err
+	}
+
+	ips := make([]map[string]interface{}, len(list))
+	for i, ip := range list {
+		ips[i] = flattenIpamIPAddress(ip)
+	}
+
+	return dataSourceListSetResults(d, dataNetboxIpamIPAddressesList().Schema,
+		"ip_addresses", ips)
+}
@@ -0,0 +1,186 @@
+package netbox
+
+import (
+	"strconv"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/ipam"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+func dataNetboxIpamPrefix() *schema.Resource {
+	return &schema.Resource{
+		Read: dataNetboxIpamPrefixRead,
+
+		Schema: dataSourceSchema(ipamPrefixAttributes(), map[string]*schema.Schema{
+			"custom_fields": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"prefix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsCIDRNetwork(0, 256),
+			},
+			"role_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"site_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"utilization": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"vlan_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"vrf_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+		}),
+	}
+}
+
+func dataNetboxIpamPrefixRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerClient)
+
+	p := ipam.NewIpamPrefixesListParams()
+
+	if prefix, ok := d.GetOk("prefix"); ok {
+		prefixStr := prefix.(string)
+		p.SetPrefix(&prefixStr)
+	}
+
+	if roleID, ok := d.GetOk("role_id"); ok {
+		roleIDStr := strconv.Itoa(roleID.(int))
+		p.SetRoleID(&roleIDStr)
+	}
+
+	if siteID, ok := d.GetOk("site_id"); ok {
+		siteIDStr := strconv.Itoa(siteID.(int))
+		p.SetSiteID(&siteIDStr)
+	}
+
+	if tenantID, ok := d.GetOk("tenant_id"); ok {
+		tenantIDStr := strconv.Itoa(tenantID.(int))
+		p.SetTenantID(&tenantIDStr)
+	}
+
+	if vlanID, ok := d.GetOk("vlan_id"); ok {
+		vlanIDStr := strconv.Itoa(vlanID.(int))
+		p.SetVlanID(&vlanIDStr)
+	}
+
+	if vrfID, ok := d.GetOk("vrf_id"); ok {
+		vrfIDStr := strconv.Itoa(vrfID.(int))
+		p.SetVrfID(&vrfIDStr)
+	}
+
+	customFields := d.Get("custom_fields").(map[string]interface{})
+
+	list, err := ipamPrefixesListWithCustomFields(client, p, customFields)
+	if err != nil {
+		return err
+	}
+
+	if len(list) != 1 {
+		return pkgerrors.New("Data results for netbox_ipam_prefix returns 0 or " +
+			"more than one result.")
+	}
+
+	prefix := list[0]
+
+	if err = setDataSourceAttributes(d, flattenIpamPrefix(prefix)); err != nil {
+		return err
+	}
+
+	utilization, err := ipamPrefixUtilization(client, prefix)
+	if err != nil {
+		return err
+	}
+
+	if err = d.Set("utilization", utilization); err != nil {
+		return err
+	}
+
+	d.SetId(strconv.FormatInt(prefix.ID, 10))
+
+	return nil
+}
+
+// ipamPrefixesListWithCustomFields lists prefixes like ipamPrefixesListAll and
+// adds a cf_<name> filter for each custom field, the generated client has no
+// parameters for them.
+func ipamPrefixesListWithCustomFields(client *providerClient,
+	params *ipam.IpamPrefixesListParams,
+	customFields map[string]interface{}) ([]*models.Prefix, error) {
+	if len(customFields) == 0 {
+		return ipamPrefixesListAll(client, params)
+	}
+
+	writer := runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest,
+		reg strfmt.Registry) error {
+		if err := params.WriteToRequest(r, reg); err != nil {
+			return err
+		}
+
+		for name, value := range customFields {
+			if err := r.SetQueryParam("cf_"+name, value.(string)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	var results []*models.Prefix
+
+	err := paginate(client, func(limit *int64, offset *int64) (int, bool,
+		error) {
+		params.WithLimit(limit).WithOffset(offset)
+
+		result, err := client.Transport.Submit(&runtime.ClientOperation{
+			ID:                 "ipam_prefixes_list",
+			Method:             "GET",
+			PathPattern:        "/ipam/prefixes/",
+			ProducesMediaTypes: []string{"application/json"},
+			ConsumesMediaTypes: []string{"application/json"},
+			Schemes:            []string{"http"},
+			Params:             writer,
+			Reader:             &ipam.IpamPrefixesListReader{},
+			Context:            params.Context,
+			Client:             params.HTTPClient,
+		})
+		if err != nil {
+			return 0, false, err
+		}
+
+		list := result.(*ipam.IpamPrefixesListOK)
+		results = append(results, list.Payload.Results...)
+		return len(list.Payload.Results), list.Payload.Next != nil, nil
+	})
+
+	return results, err
+}
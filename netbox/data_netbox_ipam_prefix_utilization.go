@@ -0,0 +1,165 @@
+package netbox
+
+import (
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/ipam"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+func dataNetboxIpamPrefixUtilization() *schema.Resource {
+	return &schema.Resource{
+		Read: dataNetboxIpamPrefixUtilizationRead,
+
+		Schema: map[string]*schema.Schema{
+			"fail_above_percent": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validation.FloatBetween(0, 100),
+			},
+			"free": computedStringSchema(),
+			"prefix_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ExactlyOneOf: []string{"prefix_id", "tags"},
+			},
+			"prefixes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"free":   computedStringSchema(),
+						"id":     computedIntSchema(),
+						"prefix": computedStringSchema(),
+						"total":  computedStringSchema(),
+						"used":   computedStringSchema(),
+						"utilization": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"tags": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ExactlyOneOf: []string{"prefix_id", "tags"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"total": computedStringSchema(),
+			"used":  computedStringSchema(),
+			"utilization": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataNetboxIpamPrefixUtilizationRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	var prefixes []*models.Prefix
+	var id string
+
+	if prefixID, ok := d.GetOk("prefix_id"); ok {
+		params := ipam.NewIpamPrefixesReadParams().WithID(
+			int64(prefixID.(int)))
+		prefix, err := client.Ipam.IpamPrefixesRead(params, nil)
+		if err != nil {
+			return err
+		}
+
+		prefixes = []*models.Prefix{prefix.Payload}
+		id = strconv.Itoa(prefixID.(int))
+	} else {
+		tagSet := d.Get("tags").(*schema.Set)
+		tags := make([]string, tagSet.Len())
+		for i, tag := range tagSet.List() {
+			tags[i] = tag.(string)
+		}
+		sort.Strings(tags)
+
+		params := ipam.NewIpamPrefixesListParams()
+		params.SetTag(tags)
+
+		var err error
+		prefixes, err = ipamPrefixesListAll(client, params)
+		if err != nil {
+			return err
+		}
+
+		if len(prefixes) == 0 {
+			return pkgerrors.New("No prefix matches the tags " +
+				strings.Join(tags, ", ") + ".")
+		}
+
+		id = strings.Join(tags, ",")
+	}
+
+	total := new(big.Int)
+	used := new(big.Int)
+	details := make([]map[string]interface{}, len(prefixes))
+
+	for i, prefix := range prefixes {
+		prefixTotal, prefixUsed, err := ipamPrefixUsage(client, prefix)
+		if err != nil {
+			return err
+		}
+
+		total.Add(total, prefixTotal)
+		used.Add(used, prefixUsed)
+
+		details[i] = map[string]interface{}{
+			"free":        new(big.Int).Sub(prefixTotal, prefixUsed).String(),
+			"id":          prefix.ID,
+			"prefix":      stringValue(prefix.Prefix),
+			"total":       prefixTotal.String(),
+			"used":        prefixUsed.String(),
+			"utilization": usagePercent(prefixTotal, prefixUsed),
+		}
+	}
+
+	utilization := usagePercent(total, used)
+
+	if err := d.Set("free", new(big.Int).Sub(total, used).String()); err != nil {
+		return err
+	}
+
+	if err := d.Set("prefixes", details); err != nil {
+		return err
+	}
+
+	if err := d.Set("total", total.String()); err != nil {
+		return err
+	}
+
+	if err := d.Set("used", used.String()); err != nil {
+		return err
+	}
+
+	if err := d.Set("utilization", utilization); err != nil {
+		return err
+	}
+
+	if limit, ok := d.GetOk("fail_above_percent"); ok &&
+		utilization > limit.(float64) {
+		return pkgerrors.New("Utilization " +
+			strconv.FormatFloat(utilization, 'f', 2, 64) + "% is above " +
+			strconv.FormatFloat(limit.(float64), 'f', -1, 64) + "% (used " +
+			used.String() + " of " + total.String() + ").")
+	}
+
+	d.SetId(id)
+
+	return nil
+}
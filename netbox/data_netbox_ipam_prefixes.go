@@ -1,11 +1,11 @@
 package netbox
 
 import (
+	"strconv"
+
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	netboxclient "github.com/tomasherout/go-netbox/netbox/client"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/tomasherout/go-netbox/netbox/client/ipam"
-	"strconv"
-	"time"
 )
 
 func dataNetboxIpamIPPrefixes() *schema.Resource {
@@ -13,6 +13,61 @@ func dataNetboxIpamIPPrefixes() *schema.Resource {
 		Read: dataNetboxIpamIPPrefixesRead,
 
 		Schema: map[string]*schema.Schema{
+			"contains": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"family": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntInSlice([]int{4, 6}),
+			},
+			"mask_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 128),
+			},
+			"order":    orderSchema(),
+			"prefixes": dataSourceListSchema(ipamPrefixAttributes()),
+			"q": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"role_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"site_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"sort_by": sortBySchema(ipamPrefixAttributes()),
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{"container", "active",
+					"reserved", "deprecated"}, false),
+			},
+			"tenant_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"vrf_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"within": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"within_include"},
+				ValidateFunc:  validation.IsCIDRNetwork(0, 128),
+			},
+			"within_include": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"within"},
+				ValidateFunc:  validation.IsCIDRNetwork(0, 128),
+			},
 			"tags": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -33,7 +88,7 @@ func dataNetboxIpamIPPrefixes() *schema.Resource {
 
 func dataNetboxIpamIPPrefixesRead(d *schema.ResourceData, m interface{}) error {
 
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
 	params := ipam.NewIpamPrefixesListParams()
 
@@ -48,22 +103,82 @@ func dataNetboxIpamIPPrefixesRead(d *schema.ResourceData, m interface{}) error {
 		params.SetTag(tagsStr)
 	}
 
-	res, err := client.Ipam.IpamPrefixesList(params, nil)
+	if contains, ok := d.GetOk("contains"); ok {
+		containsStr := contains.(string)
+		params.SetContains(&containsStr)
+	}
+
+	if family, ok := d.GetOk("family"); ok {
+		familyFloat := float64(family.(int))
+		params.SetFamily(&familyFloat)
+	}
+
+	if maskLength, ok := d.GetOk("mask_length"); ok {
+		maskLengthFloat := float64(maskLength.(int))
+		params.SetMaskLength(&maskLengthFloat)
+	}
+
+	if q, ok := d.GetOk("q"); ok {
+		qStr := q.(string)
+		params.SetQ(&qStr)
+	}
+
+	if roleID, ok := d.GetOk("role_id"); ok {
+		roleIDStr := strconv.Itoa(roleID.(int))
+		params.SetRoleID(&roleIDStr)
+	}
+
+	if siteID, ok := d.GetOk("site_id"); ok {
+		siteIDStr := strconv.Itoa(siteID.(int))
+		params.SetSiteID(&siteIDStr)
+	}
+
+	if status, ok := d.GetOk("status"); ok {
+		statusStr := status.(string)
+		params.SetStatus(&statusStr)
+	}
+
+	if tenantID, ok := d.GetOk("tenant_id"); ok {
+		tenantIDStr := strconv.Itoa(tenantID.(int))
+		params.SetTenantID(&tenantIDStr)
+	}
+
+	if vrfID, ok := d.GetOk("vrf_id"); ok {
+		vrfIDStr := strconv.Itoa(vrfID.(int))
+		params.SetVrfID(&vrfIDStr)
+	}
+
+	if within, ok := d.GetOk("within"); ok {
+		withinStr := within.(string)
+		params.SetWithin(&withinStr)
+	}
+
+	if withinInclude, ok := d.GetOk("within_include"); ok {
+		withinIncludeStr := withinInclude.(string)
+		params.SetWithinInclude(&withinIncludeStr)
+	}
+
+	res, err := ipamPrefixesListAll(client, params)
 
 	if err != nil {
 		return err
 	}
 
-	ids := make([]int64, len(res.Payload.Results))
-
-	for i, elem := range res.Payload.Results {
-		ids[i] = elem.ID
+	prefixes := make([]map[string]interface{}, len(res))
+	for i, elem := range res {
+		prefixes[i] = flattenIpamPrefix(elem)
 	}
 
-	d.Set("ids", ids)
+	err = dataSourceListSetResults(d, dataNetboxIpamIPPrefixes().Schema,
+		"prefixes", prefixes)
+	if err != nil {
+		return err
+	}
 
-	// always run
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	ids := make([]int64, len(prefixes))
+	for i, prefix := range prefixes {
+		ids[i] = prefix["id"].(int64)
+	}
 
-	return nil
+	return d.Set("ids", ids)
 }
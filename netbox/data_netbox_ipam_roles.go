@@ -0,0 +1,64 @@
+package netbox
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/tomasherout/go-netbox/netbox/client/ipam"
+)
+
+func dataNetboxIpamRoles() *schema.Resource {
+	return &schema.Resource{
+		Read: dataNetboxIpamRolesRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"order": orderSchema(),
+			"q": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"roles": dataSourceListSchema(ipamRoleAttributes()),
+			"slug": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"sort_by": sortBySchema(ipamRoleAttributes()),
+		},
+	}
+}
+
+func dataNetboxIpamRolesRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerClient)
+
+	params := ipam.NewIpamRolesListParams()
+
+	if name, ok := d.GetOk("name"); ok {
+		nameStr := name.(string)
+		params.SetName(&nameStr)
+	}
+
+	if q, ok := d.GetOk("q"); ok {
+		qStr := q.(string)
+		params.SetQ(&qStr)
+	}
+
+	if slug, ok := d.GetOk("slug"); ok {
+		slugStr := slug.(string)
+		params.SetSlug(&slugStr)
+	}
+
+	list, err := ipamRolesListAll(client, params)
+	if err != nil {
+		return err
+	}
+
+	roles := make([]map[string]interface{}, len(list))
+	for i, role := range list {
+		roles[i] = flattenIpamRole(role)
+	}
+
+	return dataSourceListSetResults(d, dataNetboxIpamRoles().Schema,
+		"roles", roles)
+}
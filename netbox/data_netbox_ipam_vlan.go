@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	pkgerrors "github.com/pkg/errors"
-	netboxclient "github.com/tomasherout/go-netbox/netbox/client"
 	"github.com/tomasherout/go-netbox/netbox/client/ipam"
 )
 
@@ -13,7 +12,7 @@ func dataNetboxIpamVlan() *schema.Resource {
 	return &schema.Resource{
 		Read: dataNetboxIpamVlanRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: dataSourceSchema(ipamVlanAttributes(), map[string]*schema.Schema{
 			"vlan_id": {
 				Type:     schema.TypeInt,
 				Required: true,
@@ -21,13 +20,14 @@ func dataNetboxIpamVlan() *schema.Resource {
 			"vlan_group_id": {
 				Type:     schema.TypeInt,
 				Optional: true,
+				Computed: true,
 			},
-		},
+		}),
 	}
 }
 
 func dataNetboxIpamVlanRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
 	id := int64(d.Get("vlan_id").(int))
 	idStr := strconv.FormatInt(id, 10)
@@ -39,17 +39,17 @@ func dataNetboxIpamVlanRead(d *schema.ResourceData, m interface{}) error {
 		p.SetGroupID(&groupIDStr)
 	}
 
-	list, err := client.Ipam.IpamVlansList(p, nil)
+	list, err := ipamVlansListAll(client, p)
 	if err != nil {
 		return err
 	}
 
-	if *list.Payload.Count == 1 {
-		d.SetId(strconv.FormatInt(list.Payload.Results[0].ID, 10))
+	if len(list) == 1 {
+		d.SetId(strconv.FormatInt(list[0].ID, 10))
 	} else {
 		return pkgerrors.New("Data results for netbox_ipam_vlan returns 0 or " +
 			"more than one result.")
 	}
 
-	return nil
+	return setDataSourceAttributes(d, flattenIpamVlan(list[0]))
 }
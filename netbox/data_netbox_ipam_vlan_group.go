@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	pkgerrors "github.com/pkg/errors"
-	netboxclient "github.com/tomasherout/go-netbox/netbox/client"
 	"github.com/tomasherout/go-netbox/netbox/client/ipam"
 )
 
@@ -15,7 +14,7 @@ func dataNetboxIpamVlanGroup() *schema.Resource {
 	return &schema.Resource{
 		Read: dataNetboxIpamVlanGroupRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: dataSourceSchema(ipamVlanGroupAttributes(), map[string]*schema.Schema{
 			"slug": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -26,13 +25,14 @@ func dataNetboxIpamVlanGroup() *schema.Resource {
 			"site_id": {
 				Type:     schema.TypeInt,
 				Optional: true,
+				Computed: true,
 			},
-		},
+		}),
 	}
 }
 
 func dataNetboxIpamVlanGroupRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
 	slug := d.Get("slug").(string)
 	siteID := d.Get("site_id").(int)
@@ -43,17 +43,17 @@ func dataNetboxIpamVlanGroupRead(d *schema.ResourceData, m interface{}) error {
 		p.SetSiteID(&siteIDStr)
 	}
 
-	list, err := client.Ipam.IpamVlanGroupsList(p, nil)
+	list, err := ipamVlanGroupsListAll(client, p)
 	if err != nil {
 		return err
 	}
 
-	if *list.Payload.Count == 1 {
-		d.SetId(strconv.FormatInt(list.Payload.Results[0].ID, 10))
+	if len(list) == 1 {
+		d.SetId(strconv.FormatInt(list[0].ID, 10))
 	} else {
 		return pkgerrors.New("Data results for netbox_ipam_vlan_group returns 0 " +
 			"or more than one result.")
 	}
 
-	return nil
+	return setDataSourceAttributes(d, flattenIpamVlanGroup(list[0]))
 }
@@ -0,0 +1,76 @@
+package netbox
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/tomasherout/go-netbox/netbox/client/ipam"
+)
+
+func dataNetboxIpamVlanGroups() *schema.Resource {
+	return &schema.Resource{
+		Read: dataNetboxIpamVlanGroupsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"order": orderSchema(),
+			"q": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"site_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"slug": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"sort_by":     sortBySchema(ipamVlanGroupAttributes()),
+			"vlan_groups": dataSourceListSchema(ipamVlanGroupAttributes()),
+		},
+	}
+}
+
+func dataNetboxIpamVlanGroupsRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	params := ipam.NewIpamVlanGroupsListParams()
+
+	if name, ok := d.GetOk("name"); ok {
+		nameStr := name.(string)
+		params.SetName(&nameStr)
+	}
+
+	if q, ok := d.GetOk("q"); ok {
+		qStr := q.(string)
+		params.SetQ(&qStr)
+	}
+
+	if siteID, ok := d.GetOk("site_id"); ok {
+		siteIDStr := strconv.Itoa(siteID.(int))
+		params.SetSiteID(&siteIDStr)
+	}
+
+	if slug, ok := d.GetOk("slug"); ok {
+		slugStr := slug.(string)
+		params.SetSlug(&slugStr)
+	}
+
+	list, err := ipamVlanGroupsListAll(client, params)
+	if err != nil {
+		return err
+	}
+
+	groups := make([]map[string]interface{}, len(list))
+	for i, group := range list {
+		groups[i] = flattenIpamVlanGroup(group)
+	}
+
+	return dataSourceListSetResults(d, dataNetboxIpamVlanGroups().Schema,
+		"vlan_groups", groups)
+}
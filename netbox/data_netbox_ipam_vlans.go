@@ -0,0 +1,124 @@
+package netbox
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/tomasherout/go-netbox/netbox/client/ipam"
+)
+
+func dataNetboxIpamVlans() *schema.Resource {
+	return &schema.Resource{
+		Read: dataNetboxIpamVlansRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"order": orderSchema(),
+			"q": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"role_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"site_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"sort_by": sortBySchema(ipamVlanAttributes()),
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{"active", "reserved",
+					"deprecated"}, false),
+			},
+			"tag": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"vlan_group_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"vlan_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 4094),
+			},
+			"vlans": dataSourceListSchema(ipamVlanAttributes()),
+		},
+	}
+}
+
+func dataNetboxIpamVlansRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerClient)
+
+	params := ipam.NewIpamVlansListParams()
+
+	if name, ok := d.GetOk("name"); ok {
+		nameStr := name.(string)
+		params.SetName(&nameStr)
+	}
+
+	if q, ok := d.GetOk("q"); ok {
+		qStr := q.(string)
+		params.SetQ(&qStr)
+	}
+
+	if roleID, ok := d.GetOk("role_id"); ok {
+		roleIDStr := strconv.Itoa(roleID.(int))
+		params.SetRoleID(&roleIDStr)
+	}
+
+	if siteID, ok := d.GetOk("site_id"); ok {
+		siteIDStr := strconv.Itoa(siteID.(int))
+		params.SetSiteID(&siteIDStr)
+	}
+
+	if status, ok := d.GetOk("status"); ok {
+		statusStr := status.(string)
+		params.SetStatus(&statusStr)
+	}
+
+	if tag, ok := d.GetOk("tag"); ok {
+		tagStr := tag.(string)
+		params.SetTag(&tagStr)
+	}
+
+	if tenantID, ok := d.GetOk("tenant_id"); ok {
+		tenantIDStr := strconv.Itoa(tenantID.(int))
+		params.SetTenantID(&tenantIDStr)
+	}
+
+	if groupID, ok := d.GetOk("vlan_group_id"); ok {
+		groupIDStr := strconv.Itoa(groupID.(int))
+		params.SetGroupID(&groupIDStr)
+	}
+
+	if vid, ok := d.GetOk("vlan_id"); ok {
+		vidStr := strconv.Itoa(vid.(int))
+		params.SetVid(&vidStr)
+	}
+
+	list, err := ipamVlansListAll(client, params)
+	if err != nil {
+		return err
+	}
+
+	vlans := make([]map[string]interface{}, len(list))
+	for i, vlan := range list {
+		vlans[i] = flattenIpamVlan(vlan)
+	}
+
+	return dataSourceListSetResults(d, dataNetboxIpamVlans().Schema,
+		"vlans", vlans)
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	pkgerrors "github.com/pkg/errors"
-	netboxclient "github.com/tomasherout/go-netbox/netbox/client"
 	"github.com/tomasherout/go-netbox/netbox/client/tenancy"
 )
 
@@ -15,7 +14,7 @@ func dataNetboxTenancyTenantGroup() *schema.Resource {
 	return &schema.Resource{
 		Read: dataNetboxTenancyTenantGroupRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: dataSourceSchema(tenancyTenantGroupAttributes(), map[string]*schema.Schema{
 			"slug": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -23,30 +22,30 @@ func dataNetboxTenancyTenantGroup() *schema.Resource {
 					regexp.MustCompile("^[-a-zA-Z0-9_]{1,50}$"),
 					"Must be like ^[-a-zA-Z0-9_]{1,50}$"),
 			},
-		},
+		}),
 	}
 }
 
 func dataNetboxTenancyTenantGroupRead(d *schema.ResourceData,
 	m interface{}) error {
 
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
 	slug := d.Get("slug").(string)
 
 	p := tenancy.NewTenancyTenantGroupsListParams().WithSlug(&slug)
 
-	list, err := client.Tenancy.TenancyTenantGroupsList(p, nil)
+	list, err := tenancyTenantGroupsListAll(client, p)
 	if err != nil {
 		return err
 	}
 
-	if *list.Payload.Count == 1 {
-		d.SetId(strconv.FormatInt(list.Payload.Results[0].ID, 10))
+	if len(list) == 1 {
+		d.SetId(strconv.FormatInt(list[0].ID, 10))
 	} else {
 		return pkgerrors.New("Data results for netbox_tenancy_tenant_group " +
 			"returns 0 or more than one result.")
 	}
 
-	return nil
+	return setDataSourceAttributes(d, flattenTenancyTenantGroup(list[0]))
 }
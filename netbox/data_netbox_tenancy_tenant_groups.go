@@ -0,0 +1,76 @@
+package netbox
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/tomasherout/go-netbox/netbox/client/tenancy"
+)
+
+func dataNetboxTenancyTenantGroups() *schema.Resource {
+	return &schema.Resource{
+		Read: dataNetboxTenancyTenantGroupsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"order": orderSchema(),
+			"parent_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"q": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"slug": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"sort_by":       sortBySchema(tenancyTenantGroupAttributes()),
+			"tenant_groups": dataSourceListSchema(tenancyTenantGroupAttributes()),
+		},
+	}
+}
+
+func dataNetboxTenancyTenantGroupsRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	params := tenancy.NewTenancyTenantGroupsListParams()
+
+	if name, ok := d.GetOk("name"); ok {
+		nameStr := name.(string)
+		params.SetName(&nameStr)
+	}
+
+	if parentID, ok := d.GetOk("parent_id"); ok {
+		parentIDStr := strconv.Itoa(parentID.(int))
+		params.SetParentID(&parentIDStr)
+	}
+
+	if q, ok := d.GetOk("q"); ok {
+		qStr := q.(string)
+		params.SetQ(&qStr)
+	}
+
+	if slug, ok := d.GetOk("slug"); ok {
+		slugStr := slug.(string)
+		params.SetSlug(&slugStr)
+	}
+
+	list, err := tenancyTenantGroupsListAll(client, params)
+	if err != nil {
+		return err
+	}
+
+	groups := make([]map[string]interface{}, len(list))
+	for i, group := range list {
+		groups[i] = flattenTenancyTenantGroup(group)
+	}
+
+	return dataSourceListSetResults(d, dataNetboxTenancyTenantGroups().Schema,
+		"tenant_groups", groups)
+}
@@ -0,0 +1,85 @@
+package netbox
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/tomasherout/go-netbox/netbox/client/tenancy"
+)
+
+func dataNetboxTenancyTenants() *schema.Resource {
+	return &schema.Resource{
+		Read: dataNetboxTenancyTenantsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"order": orderSchema(),
+			"q": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"slug": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"sort_by": sortBySchema(tenancyTenantAttributes()),
+			"tag": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tenant_group_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"tenants": dataSourceListSchema(tenancyTenantAttributes()),
+		},
+	}
+}
+
+func dataNetboxTenancyTenantsRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	params := tenancy.NewTenancyTenantsListParams()
+
+	if name, ok := d.GetOk("name"); ok {
+		nameStr := name.(string)
+		params.SetName(&nameStr)
+	}
+
+	if q, ok := d.GetOk("q"); ok {
+		qStr := q.(string)
+		params.SetQ(&qStr)
+	}
+
+	if slug, ok := d.GetOk("slug"); ok {
+		slugStr := slug.(string)
+		params.SetSlug(&slugStr)
+	}
+
+	if tag, ok := d.GetOk("tag"); ok {
+		tagStr := tag.(string)
+		params.SetTag(&tagStr)
+	}
+
+	if groupID, ok := d.GetOk("tenant_group_id"); ok {
+		groupIDStr := strconv.Itoa(groupID.(int))
+		params.SetGroupID(&groupIDStr)
+	}
+
+	list, err := tenancyTenantsListAll(client, params)
+	if err != nil {
+		return err
+	}
+
+	tenants := make([]map[string]interface{}, len(list))
+	for i, tenant := range list {
+		tenants[i] = flattenTenancyTenant(tenant)
+	}
+
+	return dataSourceListSetResults(d, dataNetboxTenancyTenants().Schema,
+		"tenants", tenants)
+}
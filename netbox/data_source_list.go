@@ -0,0 +1,197 @@
+package netbox
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// sortBySchema returns the schema of the argument choosing the attribute
+// the objects of a plural data source are sorted by.
+func sortBySchema(attributes map[string]*schema.Schema) *schema.Schema {
+	keys := []string{"id"}
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "id",
+		ValidateFunc: validation.StringInSlice(keys, false),
+	}
+}
+
+func orderSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "asc",
+		ValidateFunc: validation.StringInSlice([]string{"asc", "desc"}, false),
+	}
+}
+
+// dataSourceListSetResults sorts the objects found by a plural data source
+// according to sort_by and order, sets them under key and derives the ID of
+// the data source from its filters and results, so it only changes when one
+// of them does.
+func dataSourceListSetResults(d *schema.ResourceData,
+	s map[string]*schema.Schema, key string,
+	results []map[string]interface{}) error {
+	sortBy := d.Get("sort_by").(string)
+	desc := d.Get("order").(string) == "desc"
+
+	sort.SliceStable(results, func(i, j int) bool {
+		c := compareAttributes(results[i][sortBy], results[j][sortBy])
+		if c == 0 {
+			// the id makes the order total
+			c = compareAttributes(results[i]["id"], results[j]["id"])
+		}
+
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	if err := d.Set(key, results); err != nil {
+		return err
+	}
+
+	d.SetId(dataSourceListID(d, s, results))
+
+	return nil
+}
+
+// dataSourceListID hashes the arguments of a plural data source together
+// with the IDs of the objects found.
+func dataSourceListID(d *schema.ResourceData, s map[string]*schema.Schema,
+	results []map[string]interface{}) string {
+	var arguments []string
+	for k, v := range s {
+		if v.Computed && !v.Optional {
+			continue
+		}
+
+		value := d.Get(k)
+		if set, ok := value.(*schema.Set); ok {
+			elems := make([]string, set.Len())
+			for i, elem := range set.List() {
+				elems[i] = fmt.Sprint(elem)
+			}
+			sort.Strings(elems)
+			value = elems
+		}
+
+		arguments = append(arguments, fmt.Sprintf("%s=%v", k, value))
+	}
+	sort.Strings(arguments)
+
+	ids := make([]string, len(results))
+	for i, result := range results {
+		ids[i] = fmt.Sprint(result["id"])
+	}
+
+	hash := sha256.Sum256([]byte(strings.Join(arguments, "\n") + "\n" +
+		strings.Join(ids, ",")))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// compareAttributes compares two flattened attributes, addresses and
+// prefixes are compared numerically.
+func compareAttributes(a interface{}, b interface{}) int {
+	switch a := a.(type) {
+	case int:
+		if b, ok := b.(int); ok {
+			return compareInt64(int64(a), int64(b))
+		}
+	case int64:
+		if b, ok := b.(int64); ok {
+			return compareInt64(a, b)
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			return compareInt64(boolToInt64(a), boolToInt64(b))
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			if c, ok := compareAddresses(a, b); ok {
+				return c
+			}
+			return strings.Compare(a, b)
+		}
+	}
+
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func compareInt64(a int64, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// compareAddresses compares two IP addresses or prefixes, with or without
+// mask. The second return value is false when one of them is not an address.
+func compareAddresses(a string, b string) (int, bool) {
+	aIP, aOnes, ok := parseAddress(a)
+	if !ok {
+		return 0, false
+	}
+
+	bIP, bOnes, ok := parseAddress(b)
+	if !ok {
+		return 0, false
+	}
+
+	// IPv4 first
+	if c := compareInt64(int64(len(aIP)), int64(len(bIP))); c != 0 {
+		return c, true
+	}
+
+	if c := ipToBigInt(aIP).Cmp(ipToBigInt(bIP)); c != 0 {
+		return c, true
+	}
+
+	return compareInt64(int64(aOnes), int64(bOnes)), true
+}
+
+func parseAddress(s string) (net.IP, int, bool) {
+	ip, network, err := net.ParseCIDR(s)
+	if err != nil {
+		ip = net.ParseIP(s)
+		if ip == nil {
+			return nil, 0, false
+		}
+
+		if ip4 := ip.To4(); ip4 != nil {
+			return ip4, 32, true
+		}
+		return ip, 128, true
+	}
+
+	ones, _ := network.Mask.Size()
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4, ones, true
+	}
+	return ip, ones, true
+}
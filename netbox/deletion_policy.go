@@ -0,0 +1,115 @@
+package netbox
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/tomasherout/go-netbox/netbox/client/ipam"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+const deletionPolicyDelete = "delete"
+const deletionPolicyDeprecate = "deprecate"
+const deletionPolicyRetain = "retain"
+
+const deprecatedDescriptionPrefix = "Deprecated by terraform on "
+
+var deprecatedDescriptionRegexp = regexp.MustCompile(
+	"^" + deprecatedDescriptionPrefix + "(\\S+)$")
+
+func deletionPolicySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  deletionPolicyDelete,
+		ValidateFunc: validation.StringInSlice([]string{deletionPolicyDelete,
+			deletionPolicyDeprecate, deletionPolicyRetain}, false),
+	}
+}
+
+func quarantinePeriodSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ValidateFunc: func(v interface{}, k string) (ws []string,
+			errs []error) {
+			if _, err := time.ParseDuration(v.(string)); err != nil {
+				errs = append(errs, err)
+			}
+			return
+		},
+	}
+}
+
+// deprecatedDescription returns the description set on objects deprecated
+// instead of deleted, the timestamp is used to compute the quarantine.
+func deprecatedDescription() string {
+	return deprecatedDescriptionPrefix + time.Now().UTC().Format(time.RFC3339)
+}
+
+func ipamIPAddressDeprecate(client *providerClient, id int64,
+	address string) error {
+	params := &models.WritableIPAddress{
+		Address:     &address,
+		Description: deprecatedDescription(),
+		Status:      "deprecated",
+	}
+
+	resource := ipam.NewIpamIPAddressesPartialUpdateParams().WithData(params)
+	resource.SetID(id)
+
+	defer client.cache.forget(cacheIpamIPAddresses, id)
+
+	_, err := client.Ipam.IpamIPAddressesPartialUpdate(resource, nil)
+	return err
+}
+
+// ipamPrefixReclaimDeprecatedIPs deletes the addresses of a prefix which
+// were deprecated by terraform longer than quarantine ago, so they can be
+// allocated again. Nothing is reclaimed when quarantine is empty.
+func ipamPrefixReclaimDeprecatedIPs(client *providerClient,
+	prefix *models.Prefix, quarantine string) error {
+	if quarantine == "" {
+		return nil
+	}
+
+	period, err := time.ParseDuration(quarantine)
+	if err != nil {
+		return err
+	}
+
+	status := "deprecated"
+	params := ipam.NewIpamIPAddressesListParams().WithParent(
+		prefix.Prefix).WithStatus(&status)
+
+	params.SetVrfID(ipamPrefixVrfFilter(prefix))
+
+	list, err := ipamIPAddressesListAll(client, params)
+	if err != nil {
+		return err
+	}
+
+	for _, ip := range list {
+		match := deprecatedDescriptionRegexp.FindStringSubmatch(ip.Description)
+		if match == nil {
+			// deprecated by hand, never reclaimed
+			continue
+		}
+
+		deprecatedOn, err := time.Parse(time.RFC3339, match[1])
+		if err != nil || time.Since(deprecatedOn) < period {
+			continue
+		}
+
+		client.cache.forget(cacheIpamIPAddresses, ip.ID)
+
+		resource := ipam.NewIpamIPAddressesDeleteParams().WithID(ip.ID)
+		if _, err := client.Ipam.IpamIPAddressesDelete(resource, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
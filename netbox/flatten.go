@@ -0,0 +1,641 @@
+package netbox
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+// The functions of this file describe the attributes exported by the data
+// sources. Singular data sources set them on the object they find, plural
+// data sources return a list of them.
+
+func computedIntSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeInt,
+		Computed: true,
+	}
+}
+
+func computedStringSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+}
+
+func computedTagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// dataSourceSchema returns the attributes completed by the arguments of a
+// singular data source, arguments win over attributes of the same name.
+func dataSourceSchema(attributes map[string]*schema.Schema,
+	arguments map[string]*schema.Schema) map[string]*schema.Schema {
+	s := make(map[string]*schema.Schema, len(attributes)+len(arguments))
+
+	for k, v := range attributes {
+		s[k] = v
+	}
+
+	for k, v := range arguments {
+		s[k] = v
+	}
+
+	return s
+}
+
+// dataSourceListSchema returns the schema of the list of objects exported by
+// a plural data source.
+func dataSourceListSchema(attributes map[string]*schema.Schema) *schema.Schema {
+	elem := dataSourceSchema(attributes, map[string]*schema.Schema{
+		"id": computedIntSchema(),
+	})
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: elem,
+		},
+	}
+}
+
+// setDataSourceAttributes sets all the flattened attributes of an object.
+func setDataSourceAttributes(d *schema.ResourceData,
+	attributes map[string]interface{}) error {
+	for k, v := range attributes {
+		if k == "id" {
+			continue
+		}
+
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func circuitsCircuitAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cid":          computedStringSchema(),
+		"comments":     computedStringSchema(),
+		"commit_rate":  computedIntSchema(),
+		"description":  computedStringSchema(),
+		"install_date": computedStringSchema(),
+		"provider_id":  computedIntSchema(),
+		"status":       computedStringSchema(),
+		"tags":         computedTagsSchema(),
+		"tenant_id":    computedIntSchema(),
+		"type_id":      computedIntSchema(),
+	}
+}
+
+func flattenCircuitsCircuit(circuit *models.Circuit) map[string]interface{} {
+	attributes := map[string]interface{}{
+		"id":           circuit.ID,
+		"cid":          stringValue(circuit.Cid),
+		"comments":     circuit.Comments,
+		"commit_rate":  int64Value(circuit.CommitRate),
+		"description":  circuit.Description,
+		"install_date": "",
+		"provider_id":  0,
+		"status":       "",
+		"tags":         flattenTags(circuit.Tags),
+		"tenant_id":    0,
+		"type_id":      0,
+	}
+
+	if circuit.InstallDate != nil {
+		attributes["install_date"] = circuit.InstallDate.String()
+	}
+
+	if circuit.Provider != nil {
+		attributes["provider_id"] = circuit.Provider.ID
+	}
+
+	if circuit.Status != nil && circuit.Status.Value != nil {
+		attributes["status"] = *circuit.Status.Value
+	}
+
+	if circuit.Tenant != nil {
+		attributes["tenant_id"] = circuit.Tenant.ID
+	}
+
+	if circuit.Type != nil {
+		attributes["type_id"] = circuit.Type.ID
+	}
+
+	return attributes
+}
+
+func circuitsCircuitTerminationAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"circuit_id":     computedIntSchema(),
+		"description":    computedStringSchema(),
+		"port_speed":     computedIntSchema(),
+		"pp_info":        computedStringSchema(),
+		"site_id":        computedIntSchema(),
+		"term_side":      computedStringSchema(),
+		"upstream_speed": computedIntSchema(),
+		"xconnect_id":    computedStringSchema(),
+	}
+}
+
+func flattenCircuitsCircuitTermination(
+	termination *models.CircuitTermination) map[string]interface{} {
+	attributes := map[string]interface{}{
+		"id":             termination.ID,
+		"circuit_id":     0,
+		"description":    termination.Description,
+		"port_speed":     int64Value(termination.PortSpeed),
+		"pp_info":        termination.PpInfo,
+		"site_id":        0,
+		"term_side":      stringValue(termination.TermSide),
+		"upstream_speed": int64Value(termination.UpstreamSpeed),
+		"xconnect_id":    termination.XconnectID,
+	}
+
+	if termination.Circuit != nil {
+		attributes["circuit_id"] = termination.Circuit.ID
+	}
+
+	if termination.Site != nil {
+		attributes["site_id"] = termination.Site.ID
+	}
+
+	return attributes
+}
+
+func circuitsCircuitTypeAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"description": computedStringSchema(),
+		"name":        computedStringSchema(),
+		"slug":        computedStringSchema(),
+	}
+}
+
+func flattenCircuitsCircuitType(
+	circuitType *models.CircuitType) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          circuitType.ID,
+		"description": circuitType.Description,
+		"name":        stringValue(circuitType.Name),
+		"slug":        stringValue(circuitType.Slug),
+	}
+}
+
+func circuitsProviderAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"account":       computedStringSchema(),
+		"admin_contact": computedStringSchema(),
+		"asn":           computedIntSchema(),
+		"comments":      computedStringSchema(),
+		"name":          computedStringSchema(),
+		"noc_contact":   computedStringSchema(),
+		"portal_url":    computedStringSchema(),
+		"slug":          computedStringSchema(),
+		"tags":          computedTagsSchema(),
+	}
+}
+
+func flattenCircuitsProvider(provider *models.Provider) map[string]interface{} {
+	return map[string]interface{}{
+		"id":            provider.ID,
+		"account":       provider.Account,
+		"admin_contact": provider.AdminContact,
+		"asn":           int64Value(provider.Asn),
+		"comments":      provider.Comments,
+		"name":          stringValue(provider.Name),
+		"noc_contact":   provider.NocContact,
+		"portal_url":    provider.PortalURL.String(),
+		"slug":          stringValue(provider.Slug),
+		"tags":          flattenTags(provider.Tags),
+	}
+}
+
+func dcimSiteAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"asn":              computedIntSchema(),
+		"comments":         computedStringSchema(),
+		"contact_email":    computedStringSchema(),
+		"contact_name":     computedStringSchema(),
+		"contact_phone":    computedStringSchema(),
+		"description":      computedStringSchema(),
+		"facility":         computedStringSchema(),
+		"latitude":         computedStringSchema(),
+		"longitude":        computedStringSchema(),
+		"name":             computedStringSchema(),
+		"physical_address": computedStringSchema(),
+		"region_id":        computedIntSchema(),
+		"shipping_address": computedStringSchema(),
+		"slug":             computedStringSchema(),
+		"status":           computedStringSchema(),
+		"tags":             computedTagsSchema(),
+		"tenant_id":        computedIntSchema(),
+		"time_zone":        computedStringSchema(),
+	}
+}
+
+func flattenDcimSite(site *models.Site) map[string]interface{} {
+	attributes := map[string]interface{}{
+		"id":               site.ID,
+		"asn":              0,
+		"comments":         site.Comments,
+		"contact_email":    site.ContactEmail.String(),
+		"contact_name":     site.ContactName,
+		"contact_phone":    site.ContactPhone,
+		"description":      site.Description,
+		"facility":         site.Facility,
+		"latitude":         "",
+		"longitude":        "",
+		"name":             stringValue(site.Name),
+		"physical_address": site.PhysicalAddress,
+		"region_id":        0,
+		"shipping_address": site.ShippingAddress,
+		"slug":             stringValue(site.Slug),
+		"status":           "",
+		"tags":             flattenTags(site.Tags),
+		"tenant_id":        0,
+		"time_zone":        site.TimeZone,
+	}
+
+	if site.Asn != nil {
+		attributes["asn"] = *site.Asn
+	}
+
+	if site.Latitude != nil {
+		attributes["latitude"] = *site.Latitude
+	}
+
+	if site.Longitude != nil {
+		attributes["longitude"] = *site.Longitude
+	}
+
+	if site.Region != nil {
+		attributes["region_id"] = site.Region.ID
+	}
+
+	if site.Status != nil && site.Status.Value != nil {
+		attributes["status"] = *site.Status.Value
+	}
+
+	if site.Tenant != nil {
+		attributes["tenant_id"] = site.Tenant.ID
+	}
+
+	return attributes
+}
+
+func extrasTagAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"color":        computedStringSchema(),
+		"description":  computedStringSchema(),
+		"name":         computedStringSchema(),
+		"slug":         computedStringSchema(),
+		"tagged_items": computedIntSchema(),
+	}
+}
+
+func flattenExtrasTag(tag *models.Tag) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           tag.ID,
+		"color":        tag.Color,
+		"description":  tag.Description,
+		"name":         stringValue(tag.Name),
+		"slug":         stringValue(tag.Slug),
+		"tagged_items": tag.TaggedItems,
+	}
+}
+
+func ipamIPAddressAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"address":        computedStringSchema(),
+		"description":    computedStringSchema(),
+		"dns_name":       computedStringSchema(),
+		"family":         computedIntSchema(),
+		"interface_id":   computedIntSchema(),
+		"nat_inside_id":  computedIntSchema(),
+		"nat_outside_id": computedIntSchema(),
+		"nat_outside_ids": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeInt,
+			},
+		},
+		"role":      computedStringSchema(),
+		"status":    computedStringSchema(),
+		"tags":      computedTagsSchema(),
+		"tenant_id": computedIntSchema(),
+		"vrf_id":    computedIntSchema(),
+	}
+}
+
+// flattenIpamIPNatOutside returns the IDs of the outside addresses of an
+// address as a list, Netbox 2.x has at most one of them.
+func flattenIpamIPNatOutside(ip *models.IPAddress) []int64 {
+	ids := []int64{}
+	if ip.NatOutside != nil {
+		ids = append(ids, ip.NatOutside.ID)
+	}
+
+	return ids
+}
+
+func flattenIpamIPAddress(ip *models.IPAddress) map[string]interface{} {
+	attributes := map[string]interface{}{
+		"id":              ip.ID,
+		"address":         stringValue(ip.Address),
+		"description":     ip.Description,
+		"dns_name":        ip.DNSName,
+		"family":          0,
+		"interface_id":    0,
+		"nat_inside_id":   0,
+		"nat_outside_id":  0,
+		"nat_outside_ids": flattenIpamIPNatOutside(ip),
+		"role":            "",
+		"status":          "",
+		"tags":            flattenTags(ip.Tags),
+		"tenant_id":       0,
+		"vrf_id":          0,
+	}
+
+	if ip.Family != nil && ip.Family.Value != nil {
+		attributes["family"] = *ip.Family.Value
+	}
+
+	if ip.AssignedObjectID != nil && ip.AssignedObjectType != nil &&
+		*ip.AssignedObjectType == "dcim.interface" {
+		attributes["interface_id"] = *ip.AssignedObjectID
+	}
+
+	if ip.NatInside != nil {
+		attributes["nat_inside_id"] = ip.NatInside.ID
+	}
+
+	if ip.NatOutside != nil {
+		attributes["nat_outside_id"] = ip.NatOutside.ID
+	}
+
+	if ip.Role != nil && ip.Role.Value != nil {
+		attributes["role"] = *ip.Role.Value
+	}
+
+	if ip.Status != nil && ip.Status.Value != nil {
+		attributes["status"] = *ip.Status.Value
+	}
+
+	if ip.Tenant != nil {
+		attributes["tenant_id"] = ip.Tenant.ID
+	}
+
+	if ip.Vrf != nil {
+		attributes["vrf_id"] = ip.Vrf.ID
+	}
+
+	return attributes
+}
+
+func ipamPrefixAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"description": computedStringSchema(),
+		"family":      computedIntSchema(),
+		"is_pool": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"prefix":    computedStringSchema(),
+		"role_id":   computedIntSchema(),
+		"site_id":   computedIntSchema(),
+		"status":    computedStringSchema(),
+		"tags":      computedTagsSchema(),
+		"tenant_id": computedIntSchema(),
+		"vlan_id":   computedIntSchema(),
+		"vrf_id":    computedIntSchema(),
+	}
+}
+
+func flattenIpamPrefix(prefix *models.Prefix) map[string]interface{} {
+	attributes := map[string]interface{}{
+		"id":          prefix.ID,
+		"description": prefix.Description,
+		"family":      0,
+		"is_pool":     prefix.IsPool,
+		"prefix":      stringValue(prefix.Prefix),
+		"role_id":     0,
+		"site_id":     0,
+		"status":      "",
+		"tags":        flattenTags(prefix.Tags),
+		"tenant_id":   0,
+		"vlan_id":     0,
+		"vrf_id":      0,
+	}
+
+	if prefix.Family != nil && prefix.Family.Value != nil {
+		attributes["family"] = *prefix.Family.Value
+	}
+
+	if prefix.Role != nil {
+		attributes["role_id"] = prefix.Role.ID
+	}
+
+	if prefix.Site != nil {
+		attributes["site_id"] = prefix.Site.ID
+	}
+
+	if prefix.Status != nil && prefix.Status.Value != nil {
+		attributes["status"] = *prefix.Status.Value
+	}
+
+	if prefix.Tenant != nil {
+		attributes["tenant_id"] = prefix.Tenant.ID
+	}
+
+	if prefix.Vlan != nil {
+		attributes["vlan_id"] = prefix.Vlan.ID
+	}
+
+	if prefix.Vrf != nil {
+		attributes["vrf_id"] = prefix.Vrf.ID
+	}
+
+	return attributes
+}
+
+func ipamRoleAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"description": computedStringSchema(),
+		"name":        computedStringSchema(),
+		"slug":        computedStringSchema(),
+		"weight":      computedIntSchema(),
+	}
+}
+
+func flattenIpamRole(role *models.Role) map[string]interface{} {
+	attributes := map[string]interface{}{
+		"id":          role.ID,
+		"description": role.Description,
+		"name":        stringValue(role.Name),
+		"slug":        stringValue(role.Slug),
+		"weight":      0,
+	}
+
+	if role.Weight != nil {
+		attributes["weight"] = *role.Weight
+	}
+
+	return attributes
+}
+
+func ipamVlanAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"description":   computedStringSchema(),
+		"name":          computedStringSchema(),
+		"role_id":       computedIntSchema(),
+		"site_id":       computedIntSchema(),
+		"status":        computedStringSchema(),
+		"tags":          computedTagsSchema(),
+		"tenant_id":     computedIntSchema(),
+		"vlan_group_id": computedIntSchema(),
+		"vlan_id":       computedIntSchema(),
+	}
+}
+
+func flattenIpamVlan(vlan *models.VLAN) map[string]interface{} {
+	attributes := map[string]interface{}{
+		"id":            vlan.ID,
+		"description":   vlan.Description,
+		"name":          stringValue(vlan.Name),
+		"role_id":       0,
+		"site_id":       0,
+		"status":        "",
+		"tags":          flattenTags(vlan.Tags),
+		"tenant_id":     0,
+		"vlan_group_id": 0,
+		"vlan_id":       int64Value(vlan.Vid),
+	}
+
+	if vlan.Role != nil {
+		attributes["role_id"] = vlan.Role.ID
+	}
+
+	if vlan.Site != nil {
+		attributes["site_id"] = vlan.Site.ID
+	}
+
+	if vlan.Status != nil && vlan.Status.Value != nil {
+		attributes["status"] = *vlan.Status.Value
+	}
+
+	if vlan.Tenant != nil {
+		attributes["tenant_id"] = vlan.Tenant.ID
+	}
+
+	if vlan.Group != nil {
+		attributes["vlan_group_id"] = vlan.Group.ID
+	}
+
+	return attributes
+}
+
+func ipamVlanGroupAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"description": computedStringSchema(),
+		"name":        computedStringSchema(),
+		"site_id":     computedIntSchema(),
+		"slug":        computedStringSchema(),
+	}
+}
+
+func flattenIpamVlanGroup(group *models.VLANGroup) map[string]interface{} {
+	attributes := map[string]interface{}{
+		"id":          group.ID,
+		"description": group.Description,
+		"name":        stringValue(group.Name),
+		"site_id":     0,
+		"slug":        stringValue(group.Slug),
+	}
+
+	if group.Site != nil {
+		attributes["site_id"] = group.Site.ID
+	}
+
+	return attributes
+}
+
+func tenancyTenantAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"comments":        computedStringSchema(),
+		"description":     computedStringSchema(),
+		"name":            computedStringSchema(),
+		"slug":            computedStringSchema(),
+		"tags":            computedTagsSchema(),
+		"tenant_group_id": computedIntSchema(),
+	}
+}
+
+func flattenTenancyTenant(tenant *models.Tenant) map[string]interface{} {
+	attributes := map[string]interface{}{
+		"id":              tenant.ID,
+		"comments":        tenant.Comments,
+		"description":     tenant.Description,
+		"name":            stringValue(tenant.Name),
+		"slug":            stringValue(tenant.Slug),
+		"tags":            flattenTags(tenant.Tags),
+		"tenant_group_id": 0,
+	}
+
+	if tenant.Group != nil {
+		attributes["tenant_group_id"] = tenant.Group.ID
+	}
+
+	return attributes
+}
+
+func tenancyTenantGroupAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"description": computedStringSchema(),
+		"name":        computedStringSchema(),
+		"parent_id":   computedIntSchema(),
+		"slug":        computedStringSchema(),
+	}
+}
+
+func flattenTenancyTenantGroup(group *models.TenantGroup) map[string]interface{} {
+	attributes := map[string]interface{}{
+		"id":          group.ID,
+		"description": group.Description,
+		"name":        stringValue(group.Name),
+		"parent_id":   0,
+		"slug":        stringValue(group.Slug),
+	}
+
+	if group.Parent != nil {
+		attributes["parent_id"] = group.Parent.ID
+	}
+
+	return attributes
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func int64Value(i *int64) int64 {
+	if i == nil {
+		return 0
+	}
+
+	return *i
+}
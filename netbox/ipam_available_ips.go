@@ -0,0 +1,312 @@
+package netbox
+
+import (
+	"math/big"
+	"net"
+	"sort"
+	"strconv"
+
+	"github.com/go-openapi/runtime"
+	runtimeclient "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/tomasherout/go-netbox/netbox/client/ipam"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+// ipamPrefixesAvailableIpsBulkCreate allocates len(data) IP addresses from a
+// prefix with a single POST. The generated client only supports one object
+// per request, Netbox however accepts a list and either allocates all the
+// addresses or none of them.
+func ipamPrefixesAvailableIpsBulkCreate(client *providerClient,
+	prefixID int64, data []*models.WritableIPAddress) ([]*models.IPAddress,
+	error) {
+	params := runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest,
+		reg strfmt.Registry) error {
+		if err := r.SetTimeout(runtimeclient.DefaultTimeout); err != nil {
+			return err
+		}
+
+		if err := r.SetBodyParam(data); err != nil {
+			return err
+		}
+
+		return r.SetPathParam("id", swag.FormatInt64(prefixID))
+	})
+
+	reader := runtime.ClientResponseReaderFunc(func(
+		response runtime.ClientResponse, consumer runtime.Consumer) (interface{},
+		error) {
+		if response.Code() != 201 {
+			return nil, runtime.NewAPIError("ipam_prefixes_available-ips_create",
+				response, response.Code())
+		}
+
+		var payload []*models.IPAddress
+		if err := consumer.Consume(response.Body(), &payload); err != nil {
+			return nil, err
+		}
+
+		return payload, nil
+	})
+
+	result, err := client.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "ipam_prefixes_available-ips_create",
+		Method:             "POST",
+		PathPattern:        "/ipam/prefixes/{id}/available-ips/",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             reader,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]*models.IPAddress), nil
+}
+
+// ipamPrefixesAvailableIpsList returns up to limit free addresses of a
+// prefix without allocating them. The generated client has no parameter for
+// the limit, Netbox returns 50 addresses without it.
+func ipamPrefixesAvailableIpsList(client *providerClient, prefixID int64,
+	limit int64) ([]*models.AvailableIP, error) {
+	params := ipam.NewIpamPrefixesAvailableIpsReadParams().WithID(prefixID)
+
+	writer := runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest,
+		reg strfmt.Registry) error {
+		if err := params.WriteToRequest(r, reg); err != nil {
+			return err
+		}
+
+		return r.SetQueryParam("limit", swag.FormatInt64(limit))
+	})
+
+	result, err := client.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "ipam_prefixes_available-ips_read",
+		Method:             "GET",
+		PathPattern:        "/ipam/prefixes/{id}/available-ips/",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             writer,
+		Reader:             &ipam.IpamPrefixesAvailableIpsReadReader{},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*ipam.IpamPrefixesAvailableIpsReadOK).Payload, nil
+}
+
+// ipamPrefixAvailableIP returns the lowest free address of a prefix ignoring
+// the skipFirst first and skipLast last usable addresses. An empty string is
+// returned when no such address is free. The candidate is computed from the
+// addresses used in the prefix, the available-ips endpoint only lists the
+// lowest free addresses which may all be skipped.
+func ipamPrefixAvailableIP(client *providerClient,
+	prefix *models.Prefix, skipFirst int, skipLast int) (string, error) {
+	first, last, err := ipamPrefixUsableRange(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	first.Add(first, big.NewInt(int64(skipFirst)))
+	last.Sub(last, big.NewInt(int64(skipLast)))
+
+	addresses, err := ipamPrefixFreeRun(client, prefix, first, last, 1)
+	if err != nil || addresses == nil {
+		return "", err
+	}
+
+	return addresses[0], nil
+}
+
+// ipamPrefixContiguousIPs returns the lowest count consecutive free usable
+// addresses of a prefix, nil when the free space is too fragmented.
+func ipamPrefixContiguousIPs(client *providerClient, prefix *models.Prefix,
+	count int) ([]string, error) {
+	first, last, err := ipamPrefixUsableRange(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return ipamPrefixFreeRun(client, prefix, first, last, count)
+}
+
+// ipamPrefixFreeRun returns the lowest count consecutive addresses between
+// first and last not used in a prefix, with the mask of the prefix. nil is
+// returned when there is no such run.
+func ipamPrefixFreeRun(client *providerClient, prefix *models.Prefix,
+	first *big.Int, last *big.Int, count int) ([]string, error) {
+	_, network, err := net.ParseCIDR(*prefix.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	params := ipam.NewIpamIPAddressesListParams().WithParent(prefix.Prefix)
+	params.SetVrfID(ipamPrefixVrfFilter(prefix))
+
+	ips, err := ipamIPAddressesListAll(client, params)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make([]*big.Int, 0, len(ips)+1)
+	for _, ip := range ips {
+		addr, _, err := net.ParseCIDR(*ip.Address)
+		if err != nil {
+			return nil, err
+		}
+		used = append(used, ipToBigInt(addr))
+	}
+
+	sort.Slice(used, func(i, j int) bool {
+		return used[i].Cmp(used[j]) < 0
+	})
+
+	// the address following the range closes the last gap
+	used = append(used, new(big.Int).Add(last, big.NewInt(1)))
+
+	size := big.NewInt(int64(count))
+	start := new(big.Int).Set(first)
+	for _, value := range used {
+		if value.Cmp(start) < 0 {
+			continue
+		}
+
+		if new(big.Int).Sub(value, start).Cmp(size) >= 0 {
+			break
+		}
+
+		start.Add(value, big.NewInt(1))
+	}
+
+	end := new(big.Int).Add(start, size)
+	end.Sub(end, big.NewInt(1))
+	if end.Cmp(last) > 0 {
+		return nil, nil
+	}
+
+	ones, bits := network.Mask.Size()
+	addresses := make([]string, count)
+	for i := range addresses {
+		value := new(big.Int).Add(start, big.NewInt(int64(i)))
+		addresses[i] = bigIntToIP(value, bits/8).String() + "/" +
+			strconv.Itoa(ones)
+	}
+
+	return addresses, nil
+}
+
+// ipamPrefixFreeIPCount returns the number of usable addresses of a prefix
+// which are not yet assigned to an IP address object.
+func ipamPrefixFreeIPCount(client *providerClient,
+	prefix *models.Prefix) (*big.Int, error) {
+	first, last, err := ipamPrefixUsableRange(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int64(1)
+	params := ipam.NewIpamIPAddressesListParams().WithParent(
+		prefix.Prefix).WithLimit(&limit)
+
+	params.SetVrfID(ipamPrefixVrfFilter(prefix))
+
+	list, err := client.Ipam.IpamIPAddressesList(params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	free := new(big.Int).Sub(last, first)
+	free.Add(free, big.NewInt(1))
+	free.Sub(free, big.NewInt(*list.Payload.Count))
+
+	if free.Sign() < 0 {
+		free.SetInt64(0)
+	}
+
+	return free, nil
+}
+
+// ipamPrefixesByFamily keeps the prefixes of the given address family (4 or
+// 6) preserving their order.
+func ipamPrefixesByFamily(prefixes []*models.Prefix,
+	family int) []*models.Prefix {
+	var filtered []*models.Prefix
+
+	for _, prefix := range prefixes {
+		if prefix.Family != nil && prefix.Family.Value != nil &&
+			*prefix.Family.Value == int64(family) {
+			filtered = append(filtered, prefix)
+		}
+	}
+
+	return filtered
+}
+
+// ipamPrefixVrfFilter returns the vrf_id filter selecting the IP addresses
+// and the prefixes within a prefix the way Netbox does: those of its VRF,
+// those of the global table (null) for a prefix of the global table, and
+// those of all the VRFs (no filter) for a container of the global table.
+func ipamPrefixVrfFilter(prefix *models.Prefix) *string {
+	if prefix.Vrf != nil {
+		vrfID := strconv.FormatInt(prefix.Vrf.ID, 10)
+		return &vrfID
+	}
+
+	if prefix.Status != nil && prefix.Status.Value != nil &&
+		*prefix.Status.Value == "container" {
+		return nil
+	}
+
+	global := "null"
+	return &global
+}
+
+// ipamPrefixUsableRange returns the first and the last address of a prefix
+// that Netbox hands out. Network and broadcast addresses of IPv4 prefixes
+// are not usable unless the prefix is a pool.
+func ipamPrefixUsableRange(prefix *models.Prefix) (*big.Int, *big.Int,
+	error) {
+	_, network, err := net.ParseCIDR(*prefix.Prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ones, bits := network.Mask.Size()
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+
+	first := ipToBigInt(network.IP)
+	last := new(big.Int).Add(first, size)
+	last.Sub(last, big.NewInt(1))
+
+	if bits == 32 && ones < 31 && !prefix.IsPool {
+		first.Add(first, big.NewInt(1))
+		last.Sub(last, big.NewInt(1))
+	}
+
+	return first, last, nil
+}
+
+func ipToBigInt(ip net.IP) *big.Int {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+
+	return new(big.Int).SetBytes(ip)
+}
+
+// bigIntToIP is the reverse of ipToBigInt, size is the length of the address
+// in bytes.
+func bigIntToIP(value *big.Int, size int) net.IP {
+	ip := make(net.IP, size)
+	b := value.Bytes()
+	copy(ip[size-len(b):], b)
+
+	return ip
+}
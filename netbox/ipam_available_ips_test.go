@@ -0,0 +1,251 @@
+package netbox
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+// netboxTestIPAddresses answers the IP address list of a fake Netbox, the
+// address filter matching the host part only and the vrf_id filter accepting
+// null for the global table. The addresses are in the VRF vrfs[address] or
+// in the global table.
+func netboxTestIPAddresses(addresses []string,
+	vrfs map[string]int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filter := r.URL.Query().Get("address")
+		vrfFilter := r.URL.Query().Get("vrf_id")
+
+		results := make([]map[string]interface{}, 0)
+		for i, address := range addresses {
+			host, _, _ := net.ParseCIDR(address)
+			if filter != "" && filter != host.String() {
+				continue
+			}
+
+			vrfID, ok := vrfs[address]
+			switch vrfFilter {
+			case "":
+			case "null":
+				if ok {
+					continue
+				}
+			default:
+				if !ok || vrfFilter != strconv.FormatInt(vrfID, 10) {
+					continue
+				}
+			}
+
+			result := map[string]interface{}{
+				"id":      i + 1,
+				"address": address,
+			}
+			if ok {
+				result["vrf"] = map[string]interface{}{
+					"id":   vrfID,
+					"name": "vrf",
+				}
+			}
+			results = append(results, result)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"count":   len(results),
+			"next":    nil,
+			"results": results,
+		})
+	}
+}
+
+func TestIpamPrefixAvailableIP(t *testing.T) {
+	var used []string
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3",
+		"10.0.0.100", "10.0.0.101", "10.0.0.254"} {
+		used = append(used, ip+"/24")
+	}
+
+	client, done := newTestClient(netboxTestIPAddresses(used, nil))
+	defer done()
+
+	prefix := "10.0.0.0/24"
+
+	for _, test := range []struct {
+		skipFirst int
+		skipLast  int
+		address   string
+	}{
+		{0, 0, "10.0.0.4/24"},
+		// beyond the 50 addresses listed by the available-ips endpoint
+		{99, 0, "10.0.0.102/24"},
+		{0, 1, "10.0.0.4/24"},
+		{252, 1, "10.0.0.253/24"},
+		{253, 0, ""},
+		{100, 153, ""},
+	} {
+		address, err := ipamPrefixAvailableIP(client,
+			&models.Prefix{ID: 1, Prefix: &prefix}, test.skipFirst,
+			test.skipLast)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if address != test.address {
+			t.Errorf("skip %d first and %d last: got %q, want %q",
+				test.skipFirst, test.skipLast, address, test.address)
+		}
+	}
+}
+
+func TestIpamPrefixAvailableIPv6(t *testing.T) {
+	client, done := newTestClient(netboxTestIPAddresses([]string{
+		"2001:db8::10/64", "2001:db8::11/64"}, nil))
+	defer done()
+
+	prefix := "2001:db8::/64"
+
+	address, err := ipamPrefixAvailableIP(client,
+		&models.Prefix{ID: 1, Prefix: &prefix}, 16, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if address != "2001:db8::12/64" {
+		t.Errorf("got %q, want %q", address, "2001:db8::12/64")
+	}
+}
+
+func TestIpamIPAddressOldestDuplicate(t *testing.T) {
+	client, done := newTestClient(netboxTestIPAddresses([]string{
+		"10.0.0.1/24", "10.0.0.5/24", "10.0.0.5/32", "10.0.0.5/16"},
+		map[string]int64{"10.0.0.5/24": 7}))
+	defer done()
+
+	vrfID := int64(7)
+
+	for _, test := range []struct {
+		address string
+		vrfID   *int64
+		oldest  int64
+	}{
+		// the duplicate in VRF 7 does not count in the global table
+		{"10.0.0.5/24", nil, 3},
+		{"10.0.0.5/24", &vrfID, 2},
+		{"10.0.0.1/24", nil, 1},
+		{"10.0.0.9/24", nil, 0},
+	} {
+		oldest, err := ipamIPAddressOldestDuplicate(client, test.address,
+			test.vrfID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if oldest != test.oldest {
+			t.Errorf("%s: got %d, want %d", test.address, oldest, test.oldest)
+		}
+	}
+}
+
+func TestIpamPrefixContiguousIPs(t *testing.T) {
+	var used []string
+	for _, ip := range []string{"10.0.0.2", "10.0.0.4", "10.0.0.7",
+		"10.0.0.13"} {
+		used = append(used, ip+"/28")
+	}
+
+	client, done := newTestClient(netboxTestIPAddresses(used, nil))
+	defer done()
+
+	prefix := "10.0.0.0/28"
+
+	for _, test := range []struct {
+		count     int
+		addresses []string
+	}{
+		{1, []string{"10.0.0.1/28"}},
+		// the lowest free addresses 1, 3, 5 are not consecutive
+		{2, []string{"10.0.0.5/28", "10.0.0.6/28"}},
+		{3, []string{"10.0.0.8/28", "10.0.0.9/28", "10.0.0.10/28"}},
+		{5, []string{"10.0.0.8/28", "10.0.0.9/28", "10.0.0.10/28",
+			"10.0.0.11/28", "10.0.0.12/28"}},
+		// the broadcast address is not usable
+		{6, nil},
+	} {
+		addresses, err := ipamPrefixContiguousIPs(client,
+			&models.Prefix{ID: 1, Prefix: &prefix}, test.count)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if strings.Join(addresses, ",") != strings.Join(test.addresses, ",") {
+			t.Errorf("%d addresses: got %v, want %v", test.count, addresses,
+				test.addresses)
+		}
+	}
+}
+
+func TestIpamPrefixVrfFilter(t *testing.T) {
+	used := []string{"10.0.0.1/24", "10.0.0.2/24", "10.0.0.3/24"}
+	vrfs := map[string]int64{"10.0.0.2/24": 7, "10.0.0.3/24": 8}
+
+	client, done := newTestClient(netboxTestIPAddresses(used, vrfs))
+	defer done()
+
+	prefix := "10.0.0.0/24"
+	container := "container"
+
+	for _, test := range []struct {
+		name    string
+		prefix  *models.Prefix
+		address string
+		free    int64
+	}{
+		// the addresses of the VRFs are free in the global table
+		{
+			name:    "global table",
+			prefix:  &models.Prefix{ID: 1, Prefix: &prefix},
+			address: "10.0.0.2/24",
+			free:    253,
+		},
+		{
+			name: "VRF",
+			prefix: &models.Prefix{ID: 1, Prefix: &prefix,
+				Vrf: &models.NestedVRF{ID: 7}},
+			address: "10.0.0.1/24",
+			free:    253,
+		},
+		// a container of the global table covers all the VRFs
+		{
+			name: "global container",
+			prefix: &models.Prefix{ID: 1, Prefix: &prefix,
+				Status: &models.PrefixStatus{Value: &container}},
+			address: "10.0.0.4/24",
+			free:    251,
+		},
+	} {
+		address, err := ipamPrefixAvailableIP(client, test.prefix, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if address != test.address {
+			t.Errorf("%s: got address %q, want %q", test.name, address,
+				test.address)
+		}
+
+		free, err := ipamPrefixFreeIPCount(client, test.prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if free.Int64() != test.free {
+			t.Errorf("%s: got %s free addresses, want %d", test.name, free,
+				test.free)
+		}
+	}
+}
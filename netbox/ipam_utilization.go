@@ -0,0 +1,128 @@
+package netbox
+
+import (
+	"math/big"
+	"net"
+	"sort"
+
+	"github.com/tomasherout/go-netbox/netbox/client/ipam"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+// ipamPrefixUsage returns the capacity of a prefix and how much of it is
+// used, the same way Netbox computes the utilization: containers are filled
+// by child prefixes, other prefixes by IP addresses.
+func ipamPrefixUsage(client *providerClient,
+	prefix *models.Prefix) (*big.Int, *big.Int, error) {
+	if prefix.Status == nil || prefix.Status.Value == nil ||
+		*prefix.Status.Value != "container" {
+		first, last, err := ipamPrefixUsableRange(prefix)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		total := new(big.Int).Sub(last, first)
+		total.Add(total, big.NewInt(1))
+
+		free, err := ipamPrefixFreeIPCount(client, prefix)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return total, new(big.Int).Sub(total, free), nil
+	}
+
+	_, network, err := net.ParseCIDR(*prefix.Prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ones, bits := network.Mask.Size()
+	total := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+
+	children, err := ipamPrefixChildren(client, prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// child prefixes may overlap, the covered ranges are merged before being
+	// counted
+	type ipRange struct{ first, last *big.Int }
+	ranges := make([]ipRange, 0, len(children))
+	for _, child := range children {
+		_, childNetwork, err := net.ParseCIDR(*child.Prefix)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		childOnes, childBits := childNetwork.Mask.Size()
+		first := ipToBigInt(childNetwork.IP)
+		last := new(big.Int).Lsh(big.NewInt(1), uint(childBits-childOnes))
+		last.Add(last, first)
+		last.Sub(last, big.NewInt(1))
+
+		ranges = append(ranges, ipRange{first, last})
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].first.Cmp(ranges[j].first) < 0
+	})
+
+	used := new(big.Int)
+	var current *ipRange
+	for i := range ranges {
+		if current != nil && ranges[i].first.Cmp(current.last) <= 0 {
+			if ranges[i].last.Cmp(current.last) > 0 {
+				current.last = ranges[i].last
+			}
+			continue
+		}
+
+		if current != nil {
+			used.Add(used, new(big.Int).Sub(current.last, current.first))
+			used.Add(used, big.NewInt(1))
+		}
+		current = &ranges[i]
+	}
+
+	if current != nil {
+		used.Add(used, new(big.Int).Sub(current.last, current.first))
+		used.Add(used, big.NewInt(1))
+	}
+
+	return total, used, nil
+}
+
+// ipamPrefixUtilization returns the utilization of a prefix in percent.
+func ipamPrefixUtilization(client *providerClient,
+	prefix *models.Prefix) (float64, error) {
+	total, used, err := ipamPrefixUsage(client, prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	return usagePercent(total, used), nil
+}
+
+func usagePercent(total *big.Int, used *big.Int) float64 {
+	if total.Sign() == 0 {
+		return 0
+	}
+
+	percent, _ := new(big.Float).Quo(
+		new(big.Float).Mul(new(big.Float).SetInt(used), big.NewFloat(100)),
+		new(big.Float).SetInt(total)).Float64()
+
+	return percent
+}
+
+// ipamPrefixChildren returns the prefixes within a prefix, see
+// ipamPrefixVrfFilter for the VRFs.
+func ipamPrefixChildren(client *providerClient,
+	prefix *models.Prefix) ([]*models.Prefix, error) {
+	params := ipam.NewIpamPrefixesListParams().WithWithin(prefix.Prefix)
+
+	params.SetVrfID(ipamPrefixVrfFilter(prefix))
+
+	return ipamPrefixesListAll(client, params)
+}
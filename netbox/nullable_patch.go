@@ -0,0 +1,185 @@
+package netbox
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/go-openapi/runtime"
+	runtimeclient "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// nullablePatch is the body of a partial update. The generated models omit
+// the empty optional fields, so a field removed from the configuration is
+// never cleared in Netbox. A nullablePatch is built from such a model and
+// sends the removed fields explicitly.
+type nullablePatch map[string]interface{}
+
+// newNullablePatch returns the fields of a writable model which are set.
+// Required fields of the model left nil are dropped instead of being sent as
+// null, Netbox keeps their value on a partial update.
+func newNullablePatch(data interface{}) (nullablePatch, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var patch nullablePatch
+	if err := decoder.Decode(&patch); err != nil {
+		return nil, err
+	}
+
+	for key, value := range patch {
+		if value == nil {
+			delete(patch, key)
+		}
+	}
+
+	return patch, nil
+}
+
+// unset clears the given fields in Netbox when attribute was removed from
+// the configuration: references become null, strings empty and booleans
+// false.
+func (p nullablePatch) unset(d *schema.ResourceData, attribute string,
+	keys ...string) {
+	if !d.HasChange(attribute) {
+		return
+	}
+
+	var value interface{}
+	switch v := d.Get(attribute).(type) {
+	case int:
+		if v != 0 {
+			return
+		}
+		value = nil
+	case string:
+		if v != "" {
+			return
+		}
+		value = ""
+	case bool:
+		if v {
+			return
+		}
+		value = false
+	default:
+		return
+	}
+
+	for _, key := range keys {
+		p[key] = value
+	}
+}
+
+// copy returns a shallow copy of the patch, e.g. to update another object
+// with the same fields.
+func (p nullablePatch) copy() nullablePatch {
+	c := make(nullablePatch, len(p))
+	for key, value := range p {
+		c[key] = value
+	}
+
+	return c
+}
+
+// Kinds of the object cache of the endpoints written by
+// netboxPartialUpdate.
+var partialUpdateCacheKinds = map[string]string{
+	"/ipam/ip-addresses/{id}/": cacheIpamIPAddresses,
+	"/ipam/prefixes/{id}/":     cacheIpamPrefixes,
+	"/ipam/vlans/{id}/":        cacheIpamVlans,
+}
+
+// netboxPartialUpdate sends a patch to the object id of the endpoint path,
+// e.g. /ipam/prefixes/{id}/. The generated client only accepts the models,
+// which can not hold a null.
+func netboxPartialUpdate(client *providerClient, operation string,
+	path string, id int64, patch nullablePatch) error {
+	if kind, ok := partialUpdateCacheKinds[path]; ok {
+		defer client.cache.forget(kind, id)
+	}
+
+	return netboxSubmit(client, operation, "PATCH", path, id, patch, nil)
+}
+
+// netboxError is a failure answered by Netbox. Unlike the errors of the
+// generated client, it keeps the body explaining the failure, e.g. the
+// validation errors of a status 400.
+type netboxError struct {
+	operation string
+	code      int
+	body      string
+}
+
+func (e *netboxError) Error() string {
+	return fmt.Sprintf("%s (status %d): %s", e.operation, e.code, e.body)
+}
+
+// netboxSubmit sends a request with body to the object id of the endpoint
+// path, or to the endpoint itself when id is 0, and decodes the answer into
+// result unless nil. It serves the objects the generated models can not
+// represent.
+func netboxSubmit(client *providerClient, operation string, method string,
+	path string, id int64, body interface{}, result interface{}) error {
+	params := runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest,
+		reg strfmt.Registry) error {
+		if err := r.SetTimeout(runtimeclient.DefaultTimeout); err != nil {
+			return err
+		}
+
+		if body != nil {
+			if err := r.SetBodyParam(body); err != nil {
+				return err
+			}
+		}
+
+		if id == 0 {
+			return nil
+		}
+
+		return r.SetPathParam("id", swag.FormatInt64(id))
+	})
+
+	reader := runtime.ClientResponseReaderFunc(func(
+		response runtime.ClientResponse, consumer runtime.Consumer) (interface{},
+		error) {
+		if response.Code() < 200 || response.Code() > 299 {
+			body, _ := ioutil.ReadAll(response.Body())
+			return nil, &netboxError{
+				operation: operation,
+				code:      response.Code(),
+				body:      string(body),
+			}
+		}
+
+		if result != nil {
+			if err := consumer.Consume(response.Body(), result); err != nil {
+				return nil, err
+			}
+		}
+
+		return result, nil
+	})
+
+	_, err := client.Transport.Submit(&runtime.ClientOperation{
+		ID:                 operation,
+		Method:             method,
+		PathPattern:        path,
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             reader,
+	})
+
+	return err
+}
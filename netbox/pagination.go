@@ -0,0 +1,283 @@
+package netbox
+
+import (
+	"github.com/tomasherout/go-netbox/netbox/client/circuits"
+	"github.com/tomasherout/go-netbox/netbox/client/dcim"
+	"github.com/tomasherout/go-netbox/netbox/client/extras"
+	"github.com/tomasherout/go-netbox/netbox/client/ipam"
+	"github.com/tomasherout/go-netbox/netbox/client/tenancy"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+// paginate calls list for each page of a list endpoint until the last one,
+// with the page size of the provider. list returns the number of objects of
+// the page and whether Netbox announced a next page.
+func paginate(client *providerClient,
+	list func(limit *int64, offset *int64) (int, bool, error)) error {
+	offset := int64(0)
+
+	for {
+		limit := client.pageSize
+		pageOffset := offset
+
+		read, next, err := list(&limit, &pageOffset)
+		if err != nil {
+			return err
+		}
+
+		offset += int64(read)
+		if !next || read == 0 {
+			return nil
+		}
+	}
+}
+
+func circuitsCircuitTerminationsListAll(client *providerClient,
+	params *circuits.CircuitsCircuitTerminationsListParams) (
+	[]*models.CircuitTermination, error) {
+	var results []*models.CircuitTermination
+
+	err := paginate(client, func(limit *int64, offset *int64) (int, bool,
+		error) {
+		list, err := client.Circuits.CircuitsCircuitTerminationsList(
+			params.WithLimit(limit).WithOffset(offset), nil)
+		if err != nil {
+			return 0, false, err
+		}
+
+		results = append(results, list.Payload.Results...)
+		return len(list.Payload.Results), list.Payload.Next != nil, nil
+	})
+
+	return results, err
+}
+
+func circuitsCircuitTypesListAll(client *providerClient,
+	params *circuits.CircuitsCircuitTypesListParams) ([]*models.CircuitType,
+	error) {
+	var results []*models.CircuitType
+
+	err := paginate(client, func(limit *int64, offset *int64) (int, bool,
+		error) {
+		list, err := client.Circuits.CircuitsCircuitTypesList(
+			params.WithLimit(limit).WithOffset(offset), nil)
+		if err != nil {
+			return 0, false, err
+		}
+
+		results = append(results, list.Payload.Results...)
+		return len(list.Payload.Results), list.Payload.Next != nil, nil
+	})
+
+	return results, err
+}
+
+func circuitsCircuitsListAll(client *providerClient,
+	params *circuits.CircuitsCircuitsListParams) ([]*models.Circuit, error) {
+	var results []*models.Circuit
+
+	err := paginate(client, func(limit *int64, offset *int64) (int, bool,
+		error) {
+		list, err := client.Circuits.CircuitsCircuitsList(
+			params.WithLimit(limit).WithOffset(offset), nil)
+		if err != nil {
+			return 0, false, err
+		}
+
+		results = append(results, list.Payload.Results...)
+		return len(list.Payload.Results), list.Payload.Next != nil, nil
+	})
+
+	return results, err
+}
+
+func circuitsProvidersListAll(client *providerClient,
+	params *circuits.CircuitsProvidersListParams) ([]*models.Provider, error) {
+	var results []*models.Provider
+
+	err := paginate(client, func(limit *int64, offset *int64) (int, bool,
+		error) {
+		list, err := client.Circuits.CircuitsProvidersList(
+			params.WithLimit(limit).WithOffset(offset), nil)
+		if err != nil {
+			return 0, false, err
+		}
+
+		results = append(results, list.Payload.Results...)
+		return len(list.Payload.Results), list.Payload.Next != nil, nil
+	})
+
+	return results, err
+}
+
+func dcimSitesListAll(client *providerClient,
+	params *dcim.DcimSitesListParams) ([]*models.Site, error) {
+	var results []*models.Site
+
+	err := paginate(client, func(limit *int64, offset *int64) (int, bool,
+		error) {
+		list, err := client.Dcim.DcimSitesList(
+			params.WithLimit(limit).WithOffset(offset), nil)
+		if err != nil {
+			return 0, false, err
+		}
+
+		results = append(results, list.Payload.Results...)
+		return len(list.Payload.Results), list.Payload.Next != nil, nil
+	})
+
+	return results, err
+}
+
+func extrasTagsListAll(client *providerClient,
+	params *extras.ExtrasTagsListParams) ([]*models.Tag, error) {
+	var results []*models.Tag
+
+	err := paginate(client, func(limit *int64, offset *int64) (int, bool,
+		error) {
+		list, err := client.Extras.ExtrasTagsList(
+			params.WithLimit(limit).WithOffset(offset), nil)
+		if err != nil {
+			return 0, false, err
+		}
+
+		results = append(results, list.Payload.Results...)
+		return len(list.Payload.Results), list.Payload.Next != nil, nil
+	})
+
+	return results, err
+}
+
+func ipamIPAddressesListAll(client *providerClient,
+	params *ipam.IpamIPAddressesListParams) ([]*models.IPAddress, error) {
+	var results []*models.IPAddress
+
+	err := paginate(client, func(limit *int64, offset *int64) (int, bool,
+		error) {
+		list, err := client.Ipam.IpamIPAddressesList(
+			params.WithLimit(limit).WithOffset(offset), nil)
+		if err != nil {
+			return 0, false, err
+		}
+
+		results = append(results, list.Payload.Results...)
+		return len(list.Payload.Results), list.Payload.Next != nil, nil
+	})
+
+	return results, err
+}
+
+func ipamPrefixesListAll(client *providerClient,
+	params *ipam.IpamPrefixesListParams) ([]*models.Prefix, error) {
+	var results []*models.Prefix
+
+	err := paginate(client, func(limit *int64, offset *int64) (int, bool,
+		error) {
+		list, err := client.Ipam.IpamPrefixesList(
+			params.WithLimit(limit).WithOffset(offset), nil)
+		if err != nil {
+			return 0, false, err
+		}
+
+		results = append(results, list.Payload.Results...)
+		return len(list.Payload.Results), list.Payload.Next != nil, nil
+	})
+
+	return results, err
+}
+
+func ipamRolesListAll(client *providerClient,
+	params *ipam.IpamRolesListParams) ([]*models.Role, error) {
+	var results []*models.Role
+
+	err := paginate(client, func(limit *int64, offset *int64) (int, bool,
+		error) {
+		list, err := client.Ipam.IpamRolesList(
+			params.WithLimit(limit).WithOffset(offset), nil)
+		if err != nil {
+			return 0, false, err
+		}
+
+		results = append(results, list.Payload.Results...)
+		return len(list.Payload.Results), list.Payload.Next != nil, nil
+	})
+
+	return results, err
+}
+
+func ipamVlanGroupsListAll(client *providerClient,
+	params *ipam.IpamVlanGroupsListParams) ([]*models.VLANGroup, error) {
+	var results []*models.VLANGroup
+
+	err := paginate(client, func(limit *int64, offset *int64) (int, bool,
+		error) {
+		list, err := client.Ipam.IpamVlanGroupsList(
+			params.WithLimit(limit).WithOffset(offset), nil)
+		if err != nil {
+			return 0, false, err
+		}
+
+		results = append(results, list.Payload.Results...)
+		return len(list.Payload.Results), list.Payload.Next != nil, nil
+	})
+
+	return results, err
+}
+
+func ipamVlansListAll(client *providerClient,
+	params *ipam.IpamVlansListParams) ([]*models.VLAN, error) {
+	var results []*models.VLAN
+
+	err := paginate(client, func(limit *int64, offset *int64) (int, bool,
+		error) {
+		list, err := client.Ipam.IpamVlansList(
+			params.WithLimit(limit).WithOffset(offset), nil)
+		if err != nil {
+			return 0, false, err
+		}
+
+		results = append(results, list.Payload.Results...)
+		return len(list.Payload.Results), list.Payload.Next != nil, nil
+	})
+
+	return results, err
+}
+
+func tenancyTenantGroupsListAll(client *providerClient,
+	params *tenancy.TenancyTenantGroupsListParams) ([]*models.TenantGroup,
+	error) {
+	var results []*models.TenantGroup
+
+	err := paginate(client, func(limit *int64, offset *int64) (int, bool,
+		error) {
+		list, err := client.Tenancy.TenancyTenantGroupsList(
+			params.WithLimit(limit).WithOffset(offset), nil)
+		if err != nil {
+			return 0, false, err
+		}
+
+		results = append(results, list.Payload.Results...)
+		return len(list.Payload.Results), list.Payload.Next != nil, nil
+	})
+
+	return results, err
+}
+
+func tenancyTenantsListAll(client *providerClient,
+	params *tenancy.TenancyTenantsListParams) ([]*models.Tenant, error) {
+	var results []*models.Tenant
+
+	err := paginate(client, func(limit *int64, offset *int64) (int, bool,
+		error) {
+		list, err := client.Tenancy.TenancyTenantsList(
+			params.WithLimit(limit).WithOffset(offset), nil)
+		if err != nil {
+			return 0, false, err
+		}
+
+		results = append(results, list.Payload.Results...)
+		return len(list.Payload.Results), list.Payload.Next != nil, nil
+	})
+
+	return results, err
+}
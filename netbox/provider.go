@@ -2,16 +2,33 @@ package netbox
 
 import (
 	"fmt"
+	"time"
 
 	runtimeclient "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/tomasherout/go-netbox/netbox/client"
 )
 
 const authHeaderName = "Authorization"
 const authHeaderFormat = "Token %v"
 
+// providerClient is the meta passed to the resources, the API client
+// completed by the settings of the provider.
+type providerClient struct {
+	*client.NetBoxAPI
+
+	// Objects listed at once to answer the Reads, disabled without TTL.
+	cache *objectCache
+
+	// Tags added to the tags of every resource.
+	defaultTags []string
+
+	// Number of objects requested per page by the list calls.
+	pageSize int64
+}
+
 // Provider exports the actual provider.
 func Provider() *schema.Provider {
 	return &schema.Provider{
@@ -28,6 +45,38 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("NETBOX_TOKEN", ""),
 				Description: "Token used for API operations.",
 			},
+			"cache_ttl": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NETBOX_CACHE_TTL", ""),
+				ValidateFunc: func(v interface{}, k string) (ws []string,
+					errs []error) {
+					// the cache is disabled by default
+					if v.(string) == "" {
+						return
+					}
+
+					if _, err := time.ParseDuration(v.(string)); err != nil {
+						errs = append(errs, err)
+					}
+					return
+				},
+				Description: "Duration the objects listed to answer the " +
+					"Reads are kept, the cache is disabled when not set.",
+			},
+			"default_tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Tags added to the tags of every resource.",
+			},
+			"page_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NETBOX_PAGE_SIZE", 1000),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of objects requested per page when listing.",
+			},
 			"scheme": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -36,23 +85,52 @@ func Provider() *schema.Provider {
 			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"netbox_dcim_site":            dataNetboxDcimSite(),
-			"netbox_ipam_ip_addresses":    dataNetboxIpamIPAddresses(),
-			"netbox_ipam_role":            dataNetboxIpamRole(),
-			"netbox_ipam_vlan":            dataNetboxIpamVlan(),
-			"netbox_ipam_vlan_group":      dataNetboxIpamVlanGroup(),
-			"netbox_tenancy_tenant":       dataNetboxTenancyTenant(),
-			"netbox_tenancy_tenant_group": dataNetboxTenancyTenantGroup(),
-			"netbox_ipam_prefixes":        dataNetboxIpamIPPrefixes(),
+			"netbox_dcim_site":                    dataNetboxDcimSite(),
+			"netbox_ipam_ip_addresses":            dataNetboxIpamIPAddresses(),
+			"netbox_ipam_role":                    dataNetboxIpamRole(),
+			"netbox_ipam_vlan":                    dataNetboxIpamVlan(),
+			"netbox_ipam_vlan_group":              dataNetboxIpamVlanGroup(),
+			"netbox_tenancy_tenant":               dataNetboxTenancyTenant(),
+			"netbox_tenancy_tenant_group":         dataNetboxTenancyTenantGroup(),
+			"netbox_ipam_prefix":                  dataNetboxIpamPrefix(),
+			"netbox_ipam_prefixes":                dataNetboxIpamIPPrefixes(),
+			"netbox_ipam_ip_addresses_list":       dataNetboxIpamIPAddressesList(),
+			"netbox_ipam_roles":                   dataNetboxIpamRoles(),
+			"netbox_ipam_vlans":                   dataNetboxIpamVlans(),
+			"netbox_ipam_vlan_groups":             dataNetboxIpamVlanGroups(),
+			"netbox_tenancy_tenants":              dataNetboxTenancyTenants(),
+			"netbox_tenancy_tenant_groups":        dataNetboxTenancyTenantGroups(),
+			"netbox_dcim_sites":                   dataNetboxDcimSites(),
+			"netbox_ipam_available_ips":           dataNetboxIpamAvailableIPs(),
+			"netbox_ipam_available_prefixes":      dataNetboxIpamAvailablePrefixes(),
+			"netbox_ipam_prefix_utilization":      dataNetboxIpamPrefixUtilization(),
+			"netbox_extras_tag":                   dataNetboxExtrasTag(),
+			"netbox_config_context":               dataNetboxConfigContext(),
+			"netbox_circuits_provider":            dataNetboxCircuitsProvider(),
+			"netbox_circuits_circuit_type":        dataNetboxCircuitsCircuitType(),
+			"netbox_circuits_circuit":             dataNetboxCircuitsCircuit(),
+			"netbox_circuits_circuit_termination": dataNetboxCircuitsCircuitTermination(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"netbox_ipam_prefix":          resourceNetboxIpamPrefix(),
-			"netbox_ipam_ip_addresses":    resourceNetboxIpamIPAddresses(),
-			"netbox_ipam_vlan":            resourceNetboxIpamVlan(),
-			"netbox_ipam_vlan_group":      resourceNetboxIpamVlanGroup(),
-			"netbox_tenancy_tenant":       resourceNetboxTenancyTenant(),
-			"netbox_tenancy_tenant_group": resourceNetboxTenancyTenantGroup(),
-			"netbox_ipam_ip_by_prefix":    resourceNetboxIpamIPByPrefix(),
+			"netbox_ipam_prefix":                  resourceNetboxIpamPrefix(),
+			"netbox_ipam_role":                    resourceNetboxIpamRole(),
+			"netbox_ipam_ip_addresses":            resourceNetboxIpamIPAddresses(),
+			"netbox_ipam_vlan":                    resourceNetboxIpamVlan(),
+			"netbox_ipam_vlan_by_group":           resourceNetboxIpamVlanByGroup(),
+			"netbox_ipam_vlan_group":              resourceNetboxIpamVlanGroup(),
+			"netbox_tenancy_tenant":               resourceNetboxTenancyTenant(),
+			"netbox_tenancy_tenant_group":         resourceNetboxTenancyTenantGroup(),
+			"netbox_ipam_ip_block":                resourceNetboxIpamIPBlock(),
+			"netbox_ipam_ip_by_prefix":            resourceNetboxIpamIPByPrefix(),
+			"netbox_ipam_ip_nat":                  resourceNetboxIpamIPNat(),
+			"netbox_ipam_prefix_by_parent":        resourceNetboxIpamPrefixByParent(),
+			"netbox_ipam_service":                 resourceNetboxIpamService(),
+			"netbox_extras_tag":                   resourceNetboxExtrasTag(),
+			"netbox_extras_config_context":        resourceNetboxExtrasConfigContext(),
+			"netbox_circuits_provider":            resourceNetboxCircuitsProvider(),
+			"netbox_circuits_circuit_type":        resourceNetboxCircuitsCircuitType(),
+			"netbox_circuits_circuit":             resourceNetboxCircuitsCircuit(),
+			"netbox_circuits_circuit_termination": resourceNetboxCircuitsCircuitTermination(),
 		},
 		ConfigureFunc: configureProvider,
 	}
@@ -62,10 +140,31 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 	url := d.Get("url").(string)
 	token := d.Get("token").(string)
 	scheme := d.Get("scheme").(string)
+	pageSize := int64(d.Get("page_size").(int))
+
+	var cacheTTL time.Duration
+	if v := d.Get("cache_ttl").(string); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, err
+		}
+		cacheTTL = ttl
+	}
+
+	defaultTags := make([]string, 0)
+	for _, tag := range d.Get("default_tags").(*schema.Set).List() {
+		defaultTags = append(defaultTags, tag.(string))
+	}
 
 	defaultScheme := []string{scheme}
 
 	t := runtimeclient.New(url, client.DefaultBasePath, defaultScheme)
 	t.DefaultAuthentication = runtimeclient.APIKeyAuth(authHeaderName, "header", fmt.Sprintf(authHeaderFormat, token))
-	return client.New(t, strfmt.Default), nil
+
+	return &providerClient{
+		NetBoxAPI:   client.New(t, strfmt.Default),
+		cache:       newObjectCache(cacheTTL),
+		defaultTags: defaultTags,
+		pageSize:    pageSize,
+	}, nil
 }
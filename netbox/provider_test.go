@@ -0,0 +1,85 @@
+package netbox
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	runtimeclient "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/tomasherout/go-netbox/netbox/client"
+)
+
+var testAccProviders map[string]terraform.ResourceProvider
+var testAccProvider *schema.Provider
+
+func init() {
+	testAccProvider = Provider()
+	testAccProviders = map[string]terraform.ResourceProvider{
+		"netbox": testAccProvider,
+	}
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProviderValidateDefaults(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"url":   "127.0.0.1:8000",
+		"token": "0123456789abcdef",
+	})
+
+	warns, errs := Provider().Validate(config)
+	if len(warns) != 0 || len(errs) != 0 {
+		t.Fatalf("unexpected warnings %v and errors %v", warns, errs)
+	}
+}
+
+func TestProviderValidateCacheTTL(t *testing.T) {
+	for ttl, valid := range map[string]bool{
+		"5m":    true,
+		"1h30m": true,
+		"5":     false,
+		"soon":  false,
+	} {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"url":       "127.0.0.1:8000",
+			"token":     "0123456789abcdef",
+			"cache_ttl": ttl,
+		})
+
+		_, errs := Provider().Validate(config)
+		if valid != (len(errs) == 0) {
+			t.Errorf("cache_ttl %q: got errors %v", ttl, errs)
+		}
+	}
+}
+
+func testAccPreCheck(t *testing.T) {
+	for _, env := range []string{"NETBOX_URL", "NETBOX_TOKEN"} {
+		if os.Getenv(env) == "" {
+			t.Fatal(env + " must be set for acceptance tests")
+		}
+	}
+}
+
+// newTestClient returns a provider client talking to a fake Netbox served by
+// handler, and the function stopping the fake Netbox.
+func newTestClient(handler http.HandlerFunc) (*providerClient, func()) {
+	server := httptest.NewServer(handler)
+
+	transport := runtimeclient.New(strings.TrimPrefix(server.URL, "http://"),
+		client.DefaultBasePath, []string{"http"})
+
+	return &providerClient{
+		NetBoxAPI: client.New(transport, strfmt.Default),
+		pageSize:  1000,
+	}, server.Close
+}
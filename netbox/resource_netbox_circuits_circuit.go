@@ -0,0 +1,350 @@
+package netbox
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/circuits"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+func resourceNetboxCircuitsCircuit() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetboxCircuitsCircuitCreate,
+		Read:   resourceNetboxCircuitsCircuitRead,
+		Update: resourceNetboxCircuitsCircuitUpdate,
+		Delete: resourceNetboxCircuitsCircuitDelete,
+
+		CustomizeDiff: customizeDiffTagsAll,
+
+		Schema: map[string]*schema.Schema{
+			"cid": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 50),
+			},
+			"comments": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"commit_rate": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 200),
+			},
+			"install_date": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile("^[0-9]{4}-[0-9]{2}-[0-9]{2}$"),
+					"Must be like YYYY-MM-DD"),
+			},
+			"provider_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "active",
+				ValidateFunc: validation.StringInSlice([]string{"planned",
+					"provisioning", "active", "offline", "deprovisioning",
+					"decommissioned"}, false),
+			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
+			"tenant_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"type_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+		},
+	}
+}
+
+// circuitsCircuitInstallDate parses the install date of a circuit, nil when
+// not set.
+func circuitsCircuitInstallDate(value string) (*strfmt.Date, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, pkgerrors.New("install_date " + value +
+			" is not a valid date.")
+	}
+
+	installDate := strfmt.Date(date)
+	return &installDate, nil
+}
+
+func resourceNetboxCircuitsCircuitCreate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	cid := d.Get("cid").(string)
+	comments := d.Get("comments").(string)
+	commitRate := int64(d.Get("commit_rate").(int))
+	description := d.Get("description").(string)
+	providerID := int64(d.Get("provider_id").(int))
+	status := d.Get("status").(string)
+	tenantID := int64(d.Get("tenant_id").(int))
+	typeID := int64(d.Get("type_id").(int))
+
+	installDate, err := circuitsCircuitInstallDate(
+		d.Get("install_date").(string))
+	if err != nil {
+		return err
+	}
+
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
+	newResource := &models.WritableCircuit{
+		Cid:         &cid,
+		Comments:    comments,
+		Description: description,
+		InstallDate: installDate,
+		Provider:    &providerID,
+		Status:      status,
+		Tags:        tags,
+		Type:        &typeID,
+	}
+
+	if commitRate != 0 {
+		newResource.CommitRate = &commitRate
+	}
+
+	if tenantID != 0 {
+		newResource.Tenant = &tenantID
+	}
+
+	resource := circuits.NewCircuitsCircuitsCreateParams().WithData(
+		newResource)
+
+	resourceCreated, err := client.Circuits.CircuitsCircuitsCreate(resource,
+		nil)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.FormatInt(resourceCreated.Payload.ID, 10))
+
+	return resourceNetboxCircuitsCircuitRead(d, m)
+}
+
+func resourceNetboxCircuitsCircuitRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	params := circuits.NewCircuitsCircuitsReadParams().WithID(resourceID)
+	response, err := client.Circuits.CircuitsCircuitsRead(params, nil)
+	if err != nil {
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	resource := response.Payload
+
+	if err = d.Set("cid", resource.Cid); err != nil {
+		return err
+	}
+
+	if err = d.Set("comments", resource.Comments); err != nil {
+		return err
+	}
+
+	if err = d.Set("commit_rate", int64Value(resource.CommitRate)); err != nil {
+		return err
+	}
+
+	if err = d.Set("description", resource.Description); err != nil {
+		return err
+	}
+
+	if resource.InstallDate == nil {
+		if err = d.Set("install_date", ""); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("install_date",
+			resource.InstallDate.String()); err != nil {
+			return err
+		}
+	}
+
+	if resource.Provider == nil {
+		if err = d.Set("provider_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("provider_id", resource.Provider.ID); err != nil {
+			return err
+		}
+	}
+
+	if resource.Status == nil {
+		if err = d.Set("status", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("status", resource.Status.Value); err != nil {
+			return err
+		}
+	}
+
+	if err = setResourceTags(d, client, resource.Tags); err != nil {
+		return err
+	}
+
+	if resource.Tenant == nil {
+		if err = d.Set("tenant_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("tenant_id", resource.Tenant.ID); err != nil {
+			return err
+		}
+	}
+
+	if resource.Type == nil {
+		if err = d.Set("type_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("type_id", resource.Type.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceNetboxCircuitsCircuitUpdate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+	params := &models.WritableCircuit{}
+
+	cid := d.Get("cid").(string)
+	params.Cid = &cid
+
+	if d.HasChange("comments") {
+		params.Comments = d.Get("comments").(string)
+	}
+
+	if d.HasChange("commit_rate") {
+		commitRate := int64(d.Get("commit_rate").(int))
+		if commitRate != 0 {
+			params.CommitRate = &commitRate
+		}
+	}
+
+	if d.HasChange("description") {
+		params.Description = d.Get("description").(string)
+	}
+
+	if d.HasChange("install_date") {
+		installDate, err := circuitsCircuitInstallDate(
+			d.Get("install_date").(string))
+		if err != nil {
+			return err
+		}
+		params.InstallDate = installDate
+	}
+
+	providerID := int64(d.Get("provider_id").(int))
+	params.Provider = &providerID
+
+	if d.HasChange("status") {
+		params.Status = d.Get("status").(string)
+	}
+
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+	params.Tags = tags
+
+	if d.HasChange("tenant_id") {
+		tenantID := int64(d.Get("tenant_id").(int))
+		if tenantID != 0 {
+			params.Tenant = &tenantID
+		}
+	}
+
+	typeID := int64(d.Get("type_id").(int))
+	params.Type = &typeID
+
+	patch, err := newNullablePatch(params)
+	if err != nil {
+		return err
+	}
+
+	patch.unset(d, "comments", "comments")
+	patch.unset(d, "commit_rate", "commit_rate")
+	patch.unset(d, "description", "description")
+	patch.unset(d, "tenant_id", "tenant")
+
+	// the install date is a date or null, never an empty string
+	if d.HasChange("install_date") && d.Get("install_date").(string) == "" {
+		patch["install_date"] = nil
+	}
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	err = netboxPartialUpdate(client, "circuits_circuits_partial_update",
+		"/circuits/circuits/{id}/", resourceID, patch)
+	if err != nil {
+		return err
+	}
+
+	return resourceNetboxCircuitsCircuitRead(d, m)
+}
+
+func resourceNetboxCircuitsCircuitDelete(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	p := circuits.NewCircuitsCircuitsDeleteParams().WithID(id)
+	if _, err := client.Circuits.CircuitsCircuitsDelete(p, nil); err != nil {
+		// already deleted outside of terraform
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
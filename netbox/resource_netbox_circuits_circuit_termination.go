@@ -0,0 +1,261 @@
+package netbox
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/circuits"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+// A circuit has at most one termination per side, moving a termination to
+// another circuit or side replaces it.
+func resourceNetboxCircuitsCircuitTermination() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetboxCircuitsCircuitTerminationCreate,
+		Read:   resourceNetboxCircuitsCircuitTerminationRead,
+		Update: resourceNetboxCircuitsCircuitTerminationUpdate,
+		Delete: resourceNetboxCircuitsCircuitTerminationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"circuit_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 200),
+			},
+			"port_speed": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"pp_info": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 100),
+			},
+			"site_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"term_side": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"A", "Z"}, false),
+			},
+			"upstream_speed": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"xconnect_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 50),
+			},
+		},
+	}
+}
+
+func resourceNetboxCircuitsCircuitTerminationCreate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	circuitID := int64(d.Get("circuit_id").(int))
+	description := d.Get("description").(string)
+	portSpeed := int64(d.Get("port_speed").(int))
+	ppInfo := d.Get("pp_info").(string)
+	siteID := int64(d.Get("site_id").(int))
+	termSide := d.Get("term_side").(string)
+	upstreamSpeed := int64(d.Get("upstream_speed").(int))
+	xconnectID := d.Get("xconnect_id").(string)
+
+	newResource := &models.WritableCircuitTermination{
+		Circuit:     &circuitID,
+		Description: description,
+		PortSpeed:   &portSpeed,
+		PpInfo:      ppInfo,
+		Site:        &siteID,
+		TermSide:    &termSide,
+		XconnectID:  xconnectID,
+	}
+
+	if upstreamSpeed != 0 {
+		newResource.UpstreamSpeed = &upstreamSpeed
+	}
+
+	resource := circuits.NewCircuitsCircuitTerminationsCreateParams().WithData(
+		newResource)
+
+	resourceCreated, err := client.Circuits.CircuitsCircuitTerminationsCreate(
+		resource, nil)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.FormatInt(resourceCreated.Payload.ID, 10))
+
+	return resourceNetboxCircuitsCircuitTerminationRead(d, m)
+}
+
+func resourceNetboxCircuitsCircuitTerminationRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	params := circuits.NewCircuitsCircuitTerminationsReadParams().WithID(
+		resourceID)
+	response, err := client.Circuits.CircuitsCircuitTerminationsRead(params,
+		nil)
+	if err != nil {
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	resource := response.Payload
+
+	if resource.Circuit == nil {
+		if err = d.Set("circuit_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("circuit_id", resource.Circuit.ID); err != nil {
+			return err
+		}
+	}
+
+	if err = d.Set("description", resource.Description); err != nil {
+		return err
+	}
+
+	if err = d.Set("port_speed", resource.PortSpeed); err != nil {
+		return err
+	}
+
+	if err = d.Set("pp_info", resource.PpInfo); err != nil {
+		return err
+	}
+
+	if resource.Site == nil {
+		if err = d.Set("site_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("site_id", resource.Site.ID); err != nil {
+			return err
+		}
+	}
+
+	if err = d.Set("term_side", resource.TermSide); err != nil {
+		return err
+	}
+
+	if err = d.Set("upstream_speed",
+		int64Value(resource.UpstreamSpeed)); err != nil {
+		return err
+	}
+
+	if err = d.Set("xconnect_id", resource.XconnectID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceNetboxCircuitsCircuitTerminationUpdate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+	params := &models.WritableCircuitTermination{}
+
+	circuitID := int64(d.Get("circuit_id").(int))
+	params.Circuit = &circuitID
+
+	if d.HasChange("description") {
+		params.Description = d.Get("description").(string)
+	}
+
+	portSpeed := int64(d.Get("port_speed").(int))
+	params.PortSpeed = &portSpeed
+
+	if d.HasChange("pp_info") {
+		params.PpInfo = d.Get("pp_info").(string)
+	}
+
+	siteID := int64(d.Get("site_id").(int))
+	params.Site = &siteID
+
+	termSide := d.Get("term_side").(string)
+	params.TermSide = &termSide
+
+	if d.HasChange("upstream_speed") {
+		upstreamSpeed := int64(d.Get("upstream_speed").(int))
+		if upstreamSpeed != 0 {
+			params.UpstreamSpeed = &upstreamSpeed
+		}
+	}
+
+	if d.HasChange("xconnect_id") {
+		params.XconnectID = d.Get("xconnect_id").(string)
+	}
+
+	patch, err := newNullablePatch(params)
+	if err != nil {
+		return err
+	}
+
+	patch.unset(d, "description", "description")
+	patch.unset(d, "pp_info", "pp_info")
+	patch.unset(d, "upstream_speed", "upstream_speed")
+	patch.unset(d, "xconnect_id", "xconnect_id")
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	err = netboxPartialUpdate(client,
+		"circuits_circuit-terminations_partial_update",
+		"/circuits/circuit-terminations/{id}/", resourceID, patch)
+	if err != nil {
+		return err
+	}
+
+	return resourceNetboxCircuitsCircuitTerminationRead(d, m)
+}
+
+func resourceNetboxCircuitsCircuitTerminationDelete(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	p := circuits.NewCircuitsCircuitTerminationsDeleteParams().WithID(id)
+	_, err = client.Circuits.CircuitsCircuitTerminationsDelete(p, nil)
+	if err != nil {
+		// already deleted outside of terraform, e.g. along with the circuit
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package netbox
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/circuits"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+func resourceNetboxCircuitsCircuitType() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetboxCircuitsCircuitTypeCreate,
+		Read:   resourceNetboxCircuitsCircuitTypeRead,
+		Update: resourceNetboxCircuitsCircuitTypeUpdate,
+		Delete: resourceNetboxCircuitsCircuitTypeDelete,
+
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 200),
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 50),
+			},
+			"slug": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile("^[-a-zA-Z0-9_]{1,50}$"),
+					"Must be like ^[-a-zA-Z0-9_]{1,50}$"),
+			},
+		},
+	}
+}
+
+func resourceNetboxCircuitsCircuitTypeCreate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	description := d.Get("description").(string)
+	name := d.Get("name").(string)
+	slug := d.Get("slug").(string)
+
+	newResource := &models.CircuitType{
+		Description: description,
+		Name:        &name,
+		Slug:        &slug,
+	}
+
+	resource := circuits.NewCircuitsCircuitTypesCreateParams().WithData(
+		newResource)
+
+	resourceCreated, err := client.Circuits.CircuitsCircuitTypesCreate(
+		resource, nil)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.FormatInt(resourceCreated.Payload.ID, 10))
+
+	return resourceNetboxCircuitsCircuitTypeRead(d, m)
+}
+
+func resourceNetboxCircuitsCircuitTypeRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	params := circuits.NewCircuitsCircuitTypesReadParams().WithID(resourceID)
+	response, err := client.Circuits.CircuitsCircuitTypesRead(params, nil)
+	if err != nil {
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	resource := response.Payload
+
+	if err = d.Set("description", resource.Description); err != nil {
+		return err
+	}
+
+	if err = d.Set("name", resource.Name); err != nil {
+		return err
+	}
+
+	if err = d.Set("slug", resource.Slug); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceNetboxCircuitsCircuitTypeUpdate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+	params := &models.CircuitType{}
+
+	if d.HasChange("description") {
+		params.Description = d.Get("description").(string)
+	}
+
+	name := d.Get("name").(string)
+	params.Name = &name
+
+	slug := d.Get("slug").(string)
+	params.Slug = &slug
+
+	patch, err := newNullablePatch(params)
+	if err != nil {
+		return err
+	}
+
+	patch.unset(d, "description", "description")
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	err = netboxPartialUpdate(client, "circuits_circuit-types_partial_update",
+		"/circuits/circuit-types/{id}/", resourceID, patch)
+	if err != nil {
+		return err
+	}
+
+	return resourceNetboxCircuitsCircuitTypeRead(d, m)
+}
+
+func resourceNetboxCircuitsCircuitTypeDelete(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	p := circuits.NewCircuitsCircuitTypesDeleteParams().WithID(id)
+	if _, err := client.Circuits.CircuitsCircuitTypesDelete(p, nil); err != nil {
+		// already deleted outside of terraform
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
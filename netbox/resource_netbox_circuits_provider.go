@@ -0,0 +1,268 @@
+package netbox
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/circuits"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+func resourceNetboxCircuitsProvider() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetboxCircuitsProviderCreate,
+		Read:   resourceNetboxCircuitsProviderRead,
+		Update: resourceNetboxCircuitsProviderUpdate,
+		Delete: resourceNetboxCircuitsProviderDelete,
+
+		CustomizeDiff: customizeDiffTagsAll,
+
+		Schema: map[string]*schema.Schema{
+			"account": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 30),
+			},
+			"admin_contact": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"asn": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"comments": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 50),
+			},
+			"noc_contact": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"portal_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(1, 200),
+					validation.IsURLWithHTTPorHTTPS),
+			},
+			"slug": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile("^[-a-zA-Z0-9_]{1,50}$"),
+					"Must be like ^[-a-zA-Z0-9_]{1,50}$"),
+			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
+		},
+	}
+}
+
+func resourceNetboxCircuitsProviderCreate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	account := d.Get("account").(string)
+	adminContact := d.Get("admin_contact").(string)
+	asn := int64(d.Get("asn").(int))
+	comments := d.Get("comments").(string)
+	name := d.Get("name").(string)
+	nocContact := d.Get("noc_contact").(string)
+	portalURL := d.Get("portal_url").(string)
+	slug := d.Get("slug").(string)
+
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
+	newResource := &models.Provider{
+		Account:      account,
+		AdminContact: adminContact,
+		Comments:     comments,
+		Name:         &name,
+		NocContact:   nocContact,
+		PortalURL:    strfmt.URI(portalURL),
+		Slug:         &slug,
+		Tags:         tags,
+	}
+
+	if asn != 0 {
+		newResource.Asn = &asn
+	}
+
+	resource := circuits.NewCircuitsProvidersCreateParams().WithData(
+		newResource)
+
+	resourceCreated, err := client.Circuits.CircuitsProvidersCreate(resource,
+		nil)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.FormatInt(resourceCreated.Payload.ID, 10))
+
+	return resourceNetboxCircuitsProviderRead(d, m)
+}
+
+func resourceNetboxCircuitsProviderRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	params := circuits.NewCircuitsProvidersReadParams().WithID(resourceID)
+	response, err := client.Circuits.CircuitsProvidersRead(params, nil)
+	if err != nil {
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	resource := response.Payload
+
+	if err = d.Set("account", resource.Account); err != nil {
+		return err
+	}
+
+	if err = d.Set("admin_contact", resource.AdminContact); err != nil {
+		return err
+	}
+
+	if err = d.Set("asn", int64Value(resource.Asn)); err != nil {
+		return err
+	}
+
+	if err = d.Set("comments", resource.Comments); err != nil {
+		return err
+	}
+
+	if err = d.Set("name", resource.Name); err != nil {
+		return err
+	}
+
+	if err = d.Set("noc_contact", resource.NocContact); err != nil {
+		return err
+	}
+
+	if err = d.Set("portal_url", resource.PortalURL.String()); err != nil {
+		return err
+	}
+
+	if err = d.Set("slug", resource.Slug); err != nil {
+		return err
+	}
+
+	if err = setResourceTags(d, client, resource.Tags); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceNetboxCircuitsProviderUpdate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+	params := &models.Provider{}
+
+	if d.HasChange("account") {
+		params.Account = d.Get("account").(string)
+	}
+
+	if d.HasChange("admin_contact") {
+		params.AdminContact = d.Get("admin_contact").(string)
+	}
+
+	if d.HasChange("asn") {
+		asn := int64(d.Get("asn").(int))
+		if asn != 0 {
+			params.Asn = &asn
+		}
+	}
+
+	if d.HasChange("comments") {
+		params.Comments = d.Get("comments").(string)
+	}
+
+	name := d.Get("name").(string)
+	params.Name = &name
+
+	if d.HasChange("noc_contact") {
+		params.NocContact = d.Get("noc_contact").(string)
+	}
+
+	if d.HasChange("portal_url") {
+		params.PortalURL = strfmt.URI(d.Get("portal_url").(string))
+	}
+
+	slug := d.Get("slug").(string)
+	params.Slug = &slug
+
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+	params.Tags = tags
+
+	patch, err := newNullablePatch(params)
+	if err != nil {
+		return err
+	}
+
+	patch.unset(d, "account", "account")
+	patch.unset(d, "admin_contact", "admin_contact")
+	patch.unset(d, "asn", "asn")
+	patch.unset(d, "comments", "comments")
+	patch.unset(d, "noc_contact", "noc_contact")
+	patch.unset(d, "portal_url", "portal_url")
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	err = netboxPartialUpdate(client, "circuits_providers_partial_update",
+		"/circuits/providers/{id}/", resourceID, patch)
+	if err != nil {
+		return err
+	}
+
+	return resourceNetboxCircuitsProviderRead(d, m)
+}
+
+func resourceNetboxCircuitsProviderDelete(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	p := circuits.NewCircuitsProvidersDeleteParams().WithID(id)
+	if _, err := client.Circuits.CircuitsProvidersDelete(p, nil); err != nil {
+		// already deleted outside of terraform
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,290 @@
+package netbox
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/extras"
+)
+
+// extrasConfigContext is a config context as answered by Netbox. The
+// generated models hold data as a string while Netbox reads and writes a
+// JSON object, so config contexts go through netboxSubmit.
+type extrasConfigContext struct {
+	ID            int64                `json:"id"`
+	ClusterGroups []extrasNestedObject `json:"cluster_groups"`
+	Clusters      []extrasNestedObject `json:"clusters"`
+	Data          json.RawMessage      `json:"data"`
+	Description   string               `json:"description"`
+	IsActive      bool                 `json:"is_active"`
+	Name          string               `json:"name"`
+	Platforms     []extrasNestedObject `json:"platforms"`
+	Regions       []extrasNestedObject `json:"regions"`
+	Roles         []extrasNestedObject `json:"roles"`
+	Sites         []extrasNestedObject `json:"sites"`
+	Tags          []string             `json:"tags"`
+	TenantGroups  []extrasNestedObject `json:"tenant_groups"`
+	Tenants       []extrasNestedObject `json:"tenants"`
+	Weight        int64                `json:"weight"`
+}
+
+type extrasNestedObject struct {
+	ID int64 `json:"id"`
+}
+
+// Assignment criteria of a config context, attribute to key of the API.
+var extrasConfigContextAssignments = map[string]string{
+	"cluster_group_ids": "cluster_groups",
+	"cluster_ids":       "clusters",
+	"platform_ids":      "platforms",
+	"region_ids":        "regions",
+	"role_ids":          "roles",
+	"site_ids":          "sites",
+	"tenant_group_ids":  "tenant_groups",
+	"tenant_ids":        "tenants",
+}
+
+func resourceNetboxExtrasConfigContext() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"data": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validateJSONObject,
+			StateFunc:    normalizeJSON,
+		},
+		"description": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringLenBetween(1, 200),
+		},
+		"is_active": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringLenBetween(1, 100),
+		},
+		// assignment criterion, the config context does not carry the tags
+		"tags": {
+			Type: schema.TypeSet,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional: true,
+		},
+		"weight": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      1000,
+			ValidateFunc: validation.IntBetween(0, 32767),
+		},
+	}
+
+	for attribute := range extrasConfigContextAssignments {
+		s[attribute] = &schema.Schema{
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeInt,
+			},
+		}
+	}
+
+	return &schema.Resource{
+		Create: resourceNetboxExtrasConfigContextCreate,
+		Read:   resourceNetboxExtrasConfigContextRead,
+		Update: resourceNetboxExtrasConfigContextUpdate,
+		Delete: resourceNetboxExtrasConfigContextDelete,
+
+		Schema: s,
+	}
+}
+
+// validateJSONObject checks that a string holds a JSON object, Netbox
+// refuses the other JSON values as config context data.
+func validateJSONObject(v interface{}, k string) (ws []string,
+	errs []error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(v.(string)), &object); err != nil {
+		errs = append(errs, pkgerrors.New(k+" must be a JSON object: "+
+			err.Error()))
+	}
+
+	return
+}
+
+// normalizeJSON returns a JSON document with sorted keys and without
+// whitespace, so formatting changes do not show up as diffs.
+func normalizeJSON(v interface{}) string {
+	normalized, err := structure.NormalizeJsonString(v)
+	if err != nil {
+		// invalid documents are reported by the validation
+		return v.(string)
+	}
+
+	return normalized
+}
+
+// resourceNetboxExtrasConfigContextBody returns the whole config context as
+// written to Netbox, empty assignment lists included so criteria removed
+// from the configuration are removed from Netbox as well.
+func resourceNetboxExtrasConfigContextBody(
+	d *schema.ResourceData) nullablePatch {
+	tags := make([]string, 0)
+	for _, tag := range d.Get("tags").(*schema.Set).List() {
+		tags = append(tags, tag.(string))
+	}
+
+	body := nullablePatch{
+		"data":        json.RawMessage(d.Get("data").(string)),
+		"description": d.Get("description").(string),
+		"is_active":   d.Get("is_active").(bool),
+		"name":        d.Get("name").(string),
+		"tags":        tags,
+		"weight":      d.Get("weight").(int),
+	}
+
+	for attribute, key := range extrasConfigContextAssignments {
+		ids := make([]int64, 0)
+		for _, id := range d.Get(attribute).(*schema.Set).List() {
+			ids = append(ids, int64(id.(int)))
+		}
+		body[key] = ids
+	}
+
+	return body
+}
+
+func resourceNetboxExtrasConfigContextCreate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	var resourceCreated extrasConfigContext
+	err := netboxSubmit(client, "extras_config-contexts_create", "POST",
+		"/extras/config-contexts/", 0,
+		resourceNetboxExtrasConfigContextBody(d), &resourceCreated)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.FormatInt(resourceCreated.ID, 10))
+
+	return resourceNetboxExtrasConfigContextRead(d, m)
+}
+
+func resourceNetboxExtrasConfigContextRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	var resource extrasConfigContext
+	err = netboxSubmit(client, "extras_config-contexts_read", "GET",
+		"/extras/config-contexts/{id}/", resourceID, nil, &resource)
+	if err != nil {
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	assignments := map[string][]extrasNestedObject{
+		"cluster_group_ids": resource.ClusterGroups,
+		"cluster_ids":       resource.Clusters,
+		"platform_ids":      resource.Platforms,
+		"region_ids":        resource.Regions,
+		"role_ids":          resource.Roles,
+		"site_ids":          resource.Sites,
+		"tenant_group_ids":  resource.TenantGroups,
+		"tenant_ids":        resource.Tenants,
+	}
+
+	for attribute, objects := range assignments {
+		ids := make([]int64, len(objects))
+		for i, object := range objects {
+			ids[i] = object.ID
+		}
+
+		if err = d.Set(attribute, ids); err != nil {
+			return err
+		}
+	}
+
+	if err = d.Set("data", normalizeJSON(string(resource.Data))); err != nil {
+		return err
+	}
+
+	if err = d.Set("description", resource.Description); err != nil {
+		return err
+	}
+
+	if err = d.Set("is_active", resource.IsActive); err != nil {
+		return err
+	}
+
+	if err = d.Set("name", resource.Name); err != nil {
+		return err
+	}
+
+	if err = d.Set("tags", resource.Tags); err != nil {
+		return err
+	}
+
+	if err = d.Set("weight", resource.Weight); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceNetboxExtrasConfigContextUpdate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	err = netboxPartialUpdate(client, "extras_config-contexts_partial_update",
+		"/extras/config-contexts/{id}/", resourceID,
+		resourceNetboxExtrasConfigContextBody(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceNetboxExtrasConfigContextRead(d, m)
+}
+
+func resourceNetboxExtrasConfigContextDelete(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	p := extras.NewExtrasConfigContextsDeleteParams().WithID(id)
+	if _, err := client.Extras.ExtrasConfigContextsDelete(p, nil); err != nil {
+		// already deleted outside of terraform
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
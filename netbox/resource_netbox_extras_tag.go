@@ -0,0 +1,178 @@
+package netbox
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/extras"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+func resourceNetboxExtrasTag() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetboxExtrasTagCreate,
+		Read:   resourceNetboxExtrasTagRead,
+		Update: resourceNetboxExtrasTagUpdate,
+		Delete: resourceNetboxExtrasTagDelete,
+
+		Schema: map[string]*schema.Schema{
+			"color": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "9e9e9e",
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile("^[0-9a-f]{6}$"),
+					"Must be like ^[0-9a-f]{6}$"),
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 200),
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 100),
+			},
+			"slug": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile("^[-a-zA-Z0-9_]{1,100}$"),
+					"Must be like ^[-a-zA-Z0-9_]{1,100}$"),
+			},
+		},
+	}
+}
+
+func resourceNetboxExtrasTagCreate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	color := d.Get("color").(string)
+	description := d.Get("description").(string)
+	name := d.Get("name").(string)
+	slug := d.Get("slug").(string)
+
+	newResource := &models.Tag{
+		Color:       color,
+		Description: description,
+		Name:        &name,
+		Slug:        &slug,
+	}
+
+	resource := extras.NewExtrasTagsCreateParams().WithData(newResource)
+
+	resourceCreated, err := client.Extras.ExtrasTagsCreate(resource, nil)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.FormatInt(resourceCreated.Payload.ID, 10))
+	return resourceNetboxExtrasTagRead(d, m)
+}
+
+func resourceNetboxExtrasTagRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	params := extras.NewExtrasTagsReadParams().WithID(resourceID)
+	response, err := client.Extras.ExtrasTagsRead(params, nil)
+	if err != nil {
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	resource := response.Payload
+
+	if err = d.Set("color", resource.Color); err != nil {
+		return err
+	}
+
+	if err = d.Set("description", resource.Description); err != nil {
+		return err
+	}
+
+	if err = d.Set("name", resource.Name); err != nil {
+		return err
+	}
+
+	if err = d.Set("slug", resource.Slug); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceNetboxExtrasTagUpdate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+	params := &models.Tag{}
+
+	if d.HasChange("color") {
+		params.Color = d.Get("color").(string)
+	}
+
+	if d.HasChange("description") {
+		description := d.Get("description").(string)
+		params.Description = description
+	}
+
+	name := d.Get("name").(string)
+	params.Name = &name
+
+	slug := d.Get("slug").(string)
+	params.Slug = &slug
+
+	patch, err := newNullablePatch(params)
+	if err != nil {
+		return err
+	}
+
+	patch.unset(d, "description", "description")
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	err = netboxPartialUpdate(client, "extras_tags_partial_update",
+		"/extras/tags/{id}/", resourceID, patch)
+	if err != nil {
+		return err
+	}
+
+	return resourceNetboxExtrasTagRead(d, m)
+}
+
+func resourceNetboxExtrasTagDelete(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	resource := extras.NewExtrasTagsDeleteParams().WithID(id)
+	if _, err := client.Extras.ExtrasTagsDelete(resource, nil); err != nil {
+		// already deleted outside of terraform
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	pkgerrors "github.com/pkg/errors"
-	netboxclient "github.com/tomasherout/go-netbox/netbox/client"
 	"github.com/tomasherout/go-netbox/netbox/client/ipam"
 	"github.com/tomasherout/go-netbox/netbox/models"
 )
@@ -18,7 +17,8 @@ func resourceNetboxIpamIPAddresses() *schema.Resource {
 		Read:   resourceNetboxIpamIPAddressesRead,
 		Update: resourceNetboxIpamIPAddressesUpdate,
 		Delete: resourceNetboxIpamIPAddressesDelete,
-		Exists: resourceNetboxIpamIPAddressesExists,
+
+		CustomizeDiff: customizeDiffTagsAll,
 
 		Schema: map[string]*schema.Schema{
 			"address": {
@@ -28,6 +28,7 @@ func resourceNetboxIpamIPAddresses() *schema.Resource {
 					regexp.MustCompile("^[0-9]{1,3}.[0-9]{1,3}.[0-9]{1,3}.[0-9]{1,3}/"+
 						"[0-9]{1,2}$"), "Must be like 192.168.56.1/24"),
 			},
+			"deletion_policy": deletionPolicySchema(),
 			"description": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -52,6 +53,17 @@ func resourceNetboxIpamIPAddresses() *schema.Resource {
 			"nat_outside_id": {
 				Type:     schema.TypeInt,
 				Optional: true,
+				Computed: true,
+				Deprecated: "Netbox ignores nat_outside when written, set " +
+					"nat_inside_id on the outside address or use the " +
+					"netbox_ipam_ip_nat resource instead.",
+			},
+			"nat_outside_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
 			},
 			"role": {
 				Type:     schema.TypeString,
@@ -68,13 +80,8 @@ func resourceNetboxIpamIPAddresses() *schema.Resource {
 				ValidateFunc: validation.StringInSlice([]string{"container", "active",
 					"reserved", "deprecated", "dhcp"}, false),
 			},
-			"tags": {
-				Type: schema.TypeSet,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-				Optional: true,
-			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 			"tenant_id": {
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -89,27 +96,30 @@ func resourceNetboxIpamIPAddresses() *schema.Resource {
 
 func resourceNetboxIpamIPAddressesCreate(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
 	address := d.Get("address").(string)
 	description := d.Get("description").(string)
 	dnsName := d.Get("dns_name").(string)
 	interfaceID := int64(d.Get("interface_id").(int))
 	natInsideID := int64(d.Get("nat_inside_id").(int))
-	natOutsideID := int64(d.Get("nat_outside_id").(int))
 	role := d.Get("role").(string)
 	status := d.Get("status").(string)
-	tags := d.Get("tags").(*schema.Set).List()
 	tenantID := int64(d.Get("tenant_id").(int))
 	vrfID := int64(d.Get("vrf_id").(int))
 
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
 	newResource := &models.WritableIPAddress{
 		Address:     &address,
 		Description: description,
 		DNSName:     dnsName,
 		Role:        role,
 		Status:      status,
-		Tags:        expandToStringSlice(tags),
+		Tags:        tags,
 	}
 
 	if interfaceID != 0 {
@@ -122,10 +132,6 @@ func resourceNetboxIpamIPAddressesCreate(d *schema.ResourceData,
 		newResource.NatInside = &natInsideID
 	}
 
-	if natOutsideID != 0 {
-		newResource.NatOutside = &natOutsideID
-	}
-
 	if tenantID != 0 {
 		newResource.Tenant = &tenantID
 	}
@@ -148,124 +154,125 @@ func resourceNetboxIpamIPAddressesCreate(d *schema.ResourceData,
 
 func resourceNetboxIpamIPAddressesRead(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
-	resourceID := d.Id()
-	params := ipam.NewIpamIPAddressesListParams().WithID(&resourceID)
-	resources, err := client.Ipam.IpamIPAddressesList(params, nil)
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	resource, err := ipamIPAddressGet(client, resourceID)
 	if err != nil {
 		return err
 	}
 
-	for _, resource := range resources.Payload.Results {
-		if strconv.FormatInt(resource.ID, 10) == d.Id() {
-			if err = d.Set("address", resource.Address); err != nil {
-				return err
-			}
+	if resource == nil {
+		d.SetId("")
+		return nil
+	}
 
-			if err = d.Set("description", resource.Description); err != nil {
-				return err
-			}
+	if err = d.Set("address", resource.Address); err != nil {
+		return err
+	}
 
-			if err = d.Set("dns_name", resource.DNSName); err != nil {
-				return err
-			}
+	if err = d.Set("description", resource.Description); err != nil {
+		return err
+	}
 
-			if resource.AssignedObjectID == nil {
-				if *resource.AssignedObjectType == "dcim.interface" {
-					if err = d.Set("interface_id", resource.AssignedObjectID); err != nil {
-						return err
-					}
-				}
-			}
+	if err = d.Set("dns_name", resource.DNSName); err != nil {
+		return err
+	}
 
-			if resource.NatInside == nil {
-				if err = d.Set("nat_inside_id", nil); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("nat_inside_id", resource.NatInside.ID); err != nil {
-					return err
-				}
+	if resource.AssignedObjectID == nil {
+		if *resource.AssignedObjectType == "dcim.interface" {
+			if err = d.Set("interface_id", resource.AssignedObjectID); err != nil {
+				return err
 			}
+		}
+	}
 
-			if resource.NatOutside == nil {
-				if err = d.Set("nat_outside_id", nil); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("nat_outside_id", resource.NatOutside.ID); err != nil {
-					return err
-				}
-			}
+	if resource.NatInside == nil {
+		if err = d.Set("nat_inside_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("nat_inside_id", resource.NatInside.ID); err != nil {
+			return err
+		}
+	}
 
-			if resource.Role == nil {
-				if err = d.Set("role", nil); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("role", resource.Role.Value); err != nil {
-					return err
-				}
-			}
+	if resource.NatOutside == nil {
+		if err = d.Set("nat_outside_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("nat_outside_id", resource.NatOutside.ID); err != nil {
+			return err
+		}
+	}
 
-			if resource.Status == nil {
-				if err = d.Set("status", nil); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("status", resource.Status.Value); err != nil {
-					return err
-				}
-			}
+	err = d.Set("nat_outside_ids", flattenIpamIPNatOutside(resource))
+	if err != nil {
+		return err
+	}
 
-			if err = d.Set("tags", resource.Tags); err != nil {
-				return err
-			}
+	if resource.Role == nil {
+		if err = d.Set("role", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("role", resource.Role.Value); err != nil {
+			return err
+		}
+	}
 
-			if resource.Tenant == nil {
-				if err = d.Set("tenant_id", nil); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("tenant_id", resource.Tenant.ID); err != nil {
-					return err
-				}
-			}
+	if resource.Status == nil {
+		if err = d.Set("status", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("status", resource.Status.Value); err != nil {
+			return err
+		}
+	}
 
-			if resource.Vrf == nil {
-				if err = d.Set("vrf_id", nil); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("vrf_id", resource.Vrf.ID); err != nil {
-					return err
-				}
-			}
+	if err = setResourceTags(d, client, resource.Tags); err != nil {
+		return err
+	}
 
-			return nil
+	if resource.Tenant == nil {
+		if err = d.Set("tenant_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("tenant_id", resource.Tenant.ID); err != nil {
+			return err
 		}
 	}
 
-	d.SetId("")
+	if resource.Vrf == nil {
+		if err = d.Set("vrf_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("vrf_id", resource.Vrf.ID); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
 func resourceNetboxIpamIPAddressesUpdate(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 	params := &models.WritableIPAddress{}
 
 	address := d.Get("address").(string)
 	params.Address = &address
 
 	if d.HasChange("description") {
-		if description, exist := d.GetOk("description"); exist {
-			params.Description = description.(string)
-		} else {
-			params.Description = " "
-		}
+		params.Description = d.Get("description").(string)
 	}
 
 	if d.HasChange("dns_name") {
@@ -288,13 +295,6 @@ func resourceNetboxIpamIPAddressesUpdate(d *schema.ResourceData,
 		}
 	}
 
-	if d.HasChange("nat_outside_id") {
-		natInsideID := int64(d.Get("nat_outside_id").(int))
-		if natInsideID != 0 {
-			params.NatInside = &natInsideID
-		}
-	}
-
 	if d.HasChange("role") {
 		role := d.Get("role").(string)
 		params.Role = role
@@ -305,8 +305,11 @@ func resourceNetboxIpamIPAddressesUpdate(d *schema.ResourceData,
 		params.Status = status
 	}
 
-	tags := d.Get("tags").(*schema.Set).List()
-	params.Tags = expandToStringSlice(tags)
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+	params.Tags = tags
 
 	if d.HasChange("tenant_id") {
 		tenantID := int64(d.Get("tenant_id").(int))
@@ -322,17 +325,27 @@ func resourceNetboxIpamIPAddressesUpdate(d *schema.ResourceData,
 		}
 	}
 
-	resource := ipam.NewIpamIPAddressesPartialUpdateParams().WithData(
-		params)
+	patch, err := newNullablePatch(params)
+	if err != nil {
+		return err
+	}
+
+	patch.unset(d, "description", "description")
+	patch.unset(d, "dns_name", "dns_name")
+	patch.unset(d, "interface_id", "assigned_object_type",
+		"assigned_object_id")
+	patch.unset(d, "nat_inside_id", "nat_inside")
+	patch.unset(d, "role", "role")
+	patch.unset(d, "tenant_id", "tenant")
+	patch.unset(d, "vrf_id", "vrf")
 
 	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return pkgerrors.New("Unable to convert ID into int64")
 	}
 
-	resource.SetID(resourceID)
-
-	_, err = client.Ipam.IpamIPAddressesPartialUpdate(resource, nil)
+	err = netboxPartialUpdate(client, "ipam_ip-addresses_partial_update",
+		"/ipam/ip-addresses/{id}/", resourceID, patch)
 	if err != nil {
 		return err
 	}
@@ -342,47 +355,37 @@ func resourceNetboxIpamIPAddressesUpdate(d *schema.ResourceData,
 
 func resourceNetboxIpamIPAddressesDelete(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
-	resourceExists, err := resourceNetboxIpamIPAddressesExists(d, m)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return err
+		return pkgerrors.New("Unable to convert ID into int64")
 	}
 
-	if !resourceExists {
+	switch d.Get("deletion_policy").(string) {
+	case deletionPolicyRetain:
 		return nil
+	case deletionPolicyDeprecate:
+		err = ipamIPAddressDeprecate(client, id, d.Get("address").(string))
+		if err != nil {
+			// already deleted outside of terraform
+			if m, _ := regexp.MatchString("status 404", err.Error()); m {
+				return nil
+			}
+		}
+		return err
 	}
 
-	id, err := strconv.ParseInt(d.Id(), 10, 64)
-	if err != nil {
-		return pkgerrors.New("Unable to convert ID into int64")
-	}
+	defer client.cache.forget(cacheIpamIPAddresses, id)
 
 	resource := ipam.NewIpamIPAddressesDeleteParams().WithID(id)
 	if _, err := client.Ipam.IpamIPAddressesDelete(resource, nil); err != nil {
+		// already deleted outside of terraform
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			return nil
+		}
 		return err
 	}
 
 	return nil
 }
-
-func resourceNetboxIpamIPAddressesExists(d *schema.ResourceData,
-	m interface{}) (b bool, e error) {
-	client := m.(*netboxclient.NetBoxAPI)
-	resourceExist := false
-
-	resourceID := d.Id()
-	params := ipam.NewIpamIPAddressesListParams().WithID(&resourceID)
-	resources, err := client.Ipam.IpamIPAddressesList(params, nil)
-	if err != nil {
-		return resourceExist, err
-	}
-
-	for _, resource := range resources.Payload.Results {
-		if strconv.FormatInt(resource.ID, 10) == d.Id() {
-			resourceExist = true
-		}
-	}
-
-	return resourceExist, nil
-}
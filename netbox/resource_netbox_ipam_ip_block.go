@@ -0,0 +1,423 @@
+package netbox
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/ipam"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+// Number of times the creation of a contiguous block is retried when another
+// client allocates some of its addresses at the same time.
+const ipBlockCreateRetries = 5
+
+func resourceNetboxIpamIPBlock() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetboxIpamIPBlockCreate,
+		Read:   resourceNetboxIpamIPBlockRead,
+		Update: resourceNetboxIpamIPBlockUpdate,
+		Delete: resourceNetboxIpamIPBlockDelete,
+
+		CustomizeDiff: customizeDiffTagsAll,
+
+		Schema: map[string]*schema.Schema{
+			"address_count": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"addresses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"contiguous": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"deletion_policy": deletionPolicySchema(),
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 100),
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"quarantine_period": quarantinePeriodSchema(),
+			"search_prefix_ids": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "active",
+				ValidateFunc: validation.StringInSlice([]string{"active",
+					"reserved", "deprecated", "dhcp"}, false),
+			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
+			"tenant_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceNetboxIpamIPBlockCreate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	count := d.Get("address_count").(int)
+	contiguous := d.Get("contiguous").(bool)
+	description := d.Get("description").(string)
+	prefixIDs := d.Get("search_prefix_ids").([]interface{})
+	status := d.Get("status").(string)
+	tenantID := int64(d.Get("tenant_id").(int))
+
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
+	if len(prefixIDs) == 0 {
+		return pkgerrors.New("search_prefix_ids cannot be empty")
+	}
+
+	data := make([]*models.WritableIPAddress, count)
+	for i := range data {
+		data[i] = &models.WritableIPAddress{
+			Description: description,
+			Status:      status,
+			Tags:        tags,
+		}
+
+		if tenantID != 0 {
+			data[i].Tenant = &tenantID
+		}
+	}
+
+	quarantine := d.Get("quarantine_period").(string)
+
+	for _, prefixID := range prefixIDs {
+		var prefix *models.Prefix
+		if quarantine != "" || contiguous {
+			params := ipam.NewIpamPrefixesReadParams().WithID(
+				int64(prefixID.(int)))
+			resource, err := client.Ipam.IpamPrefixesRead(params, nil)
+			if err != nil {
+				return err
+			}
+			prefix = resource.Payload
+		}
+
+		if quarantine != "" {
+			if err = ipamPrefixReclaimDeprecatedIPs(client, prefix,
+				quarantine); err != nil {
+				return err
+			}
+		}
+
+		var ips []*models.IPAddress
+		if contiguous {
+			// Netbox hands out the lowest free addresses whether they follow
+			// each other or not, the block is picked here and created
+			// explicitly.
+			ips, err = resourceNetboxIpamIPBlockCreateContiguous(client,
+				prefix, data)
+			if err != nil {
+				return err
+			}
+
+			if ips == nil {
+				continue
+			}
+		} else {
+			ips, err = ipamPrefixesAvailableIpsBulkCreate(client,
+				int64(prefixID.(int)), data)
+			if err != nil {
+				// Netbox answers with status 204 when the prefix is too small
+				if m, _ := regexp.MatchString("status 204", err.Error()); m {
+					continue
+				}
+				return err
+			}
+		}
+
+		ids := make([]string, len(ips))
+		for i, ip := range ips {
+			ids[i] = strconv.FormatInt(ip.ID, 10)
+		}
+
+		d.SetId(strings.Join(ids, ","))
+
+		return resourceNetboxIpamIPBlockRead(d, m)
+	}
+
+	return pkgerrors.New("None of the prefixes has " + strconv.Itoa(count) +
+		" free IP addresses available.")
+}
+
+// resourceNetboxIpamIPBlockCreateContiguous creates the lowest len(data)
+// consecutive free addresses of a prefix in a single request and returns
+// them, nil when the free space of the prefix is too fragmented.
+//
+// Picking the addresses and creating them are two requests, another client
+// may create some of the addresses in between. The addresses are looked up
+// again once created and when any of them is held by an older object, the
+// whole block is deleted and picked again.
+func resourceNetboxIpamIPBlockCreateContiguous(client *providerClient,
+	prefix *models.Prefix, data []*models.WritableIPAddress) (
+	[]*models.IPAddress, error) {
+	var vrfID *int64
+	if prefix.Vrf != nil {
+		vrfID = &prefix.Vrf.ID
+	}
+
+	for attempt := 1; ; attempt++ {
+		addresses, err := ipamPrefixContiguousIPs(client, prefix, len(data))
+		if err != nil || addresses == nil {
+			return nil, err
+		}
+
+		for i := range data {
+			data[i].Address = &addresses[i]
+			data[i].Vrf = vrfID
+		}
+
+		var ips []*models.IPAddress
+		err = netboxSubmit(client, "ipam_ip-addresses_create", "POST",
+			"/ipam/ip-addresses/", 0, data, &ips)
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make([]string, len(ips))
+		duplicated := false
+		for i, ip := range ips {
+			ids[i] = strconv.FormatInt(ip.ID, 10)
+
+			oldest, err := ipamIPAddressOldestDuplicate(client, *ip.Address,
+				vrfID)
+			if err != nil {
+				return nil, err
+			}
+
+			if oldest != 0 && oldest != ip.ID {
+				duplicated = true
+			}
+		}
+
+		if !duplicated {
+			return ips, nil
+		}
+
+		if err = resourceNetboxIpamIPBlockDeleteIDs(client, ids); err != nil {
+			return nil, err
+		}
+
+		if attempt >= ipBlockCreateRetries {
+			return nil, pkgerrors.New("Addresses " + addresses[0] + " to " +
+				addresses[len(addresses)-1] + " were allocated by another " +
+				"client at the same time, giving up after " +
+				strconv.Itoa(attempt) + " attempts.")
+		}
+	}
+}
+
+func resourceNetboxIpamIPBlockRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	ids := strings.Split(d.Id(), ",")
+	addresses := make([]string, len(ids))
+	idsInt64 := make([]int64, len(ids))
+
+	var payload *models.IPAddress
+	for i, id := range ids {
+		idInt64, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return pkgerrors.New("Unable to convert ID into int64")
+		}
+
+		payload, err = ipamIPAddressGet(client, idInt64)
+		if err != nil {
+			return err
+		}
+
+		if payload == nil {
+			// One of the addresses is gone, the block has to be allocated
+			// again
+			d.SetId("")
+			return nil
+		}
+
+		addresses[i] = *payload.Address
+		idsInt64[i] = idInt64
+	}
+
+	if err := d.Set("addresses", addresses); err != nil {
+		return err
+	}
+
+	if err := d.Set("ids", idsInt64); err != nil {
+		return err
+	}
+
+	// All the addresses of the block share the same attributes, the last one
+	// read is used as reference
+	if err := d.Set("description", payload.Description); err != nil {
+		return err
+	}
+
+	if payload.Status == nil {
+		if err := d.Set("status", nil); err != nil {
+			return err
+		}
+	} else {
+		if err := d.Set("status", payload.Status.Value); err != nil {
+			return err
+		}
+	}
+
+	if err := setResourceTags(d, client, payload.Tags); err != nil {
+		return err
+	}
+
+	if payload.Tenant == nil {
+		if err := d.Set("tenant_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err := d.Set("tenant_id", payload.Tenant.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceNetboxIpamIPBlockUpdate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	addresses := d.Get("addresses").([]interface{})
+
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
+	for i, id := range strings.Split(d.Id(), ",") {
+		params := &models.WritableIPAddress{}
+
+		address := addresses[i].(string)
+		params.Address = &address
+
+		if d.HasChange("description") {
+			params.Description = d.Get("description").(string)
+		}
+
+		if d.HasChange("status") {
+			params.Status = d.Get("status").(string)
+		}
+
+		params.Tags = tags
+
+		if d.HasChange("tenant_id") {
+			tenantID := int64(d.Get("tenant_id").(int))
+			if tenantID != 0 {
+				params.Tenant = &tenantID
+			}
+		}
+
+		patch, err := newNullablePatch(params)
+		if err != nil {
+			return err
+		}
+
+		patch.unset(d, "description", "description")
+		patch.unset(d, "tenant_id", "tenant")
+
+		resourceID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return pkgerrors.New("Unable to convert ID into int64")
+		}
+
+		err = netboxPartialUpdate(client, "ipam_ip-addresses_partial_update",
+			"/ipam/ip-addresses/{id}/", resourceID, patch)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceNetboxIpamIPBlockRead(d, m)
+}
+
+func resourceNetboxIpamIPBlockDelete(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	switch d.Get("deletion_policy").(string) {
+	case deletionPolicyRetain:
+	case deletionPolicyDeprecate:
+		addresses := d.Get("addresses").([]interface{})
+		for i, id := range d.Get("ids").([]interface{}) {
+			if err := ipamIPAddressDeprecate(client, int64(id.(int)),
+				addresses[i].(string)); err != nil {
+				return err
+			}
+		}
+	default:
+		if err := resourceNetboxIpamIPBlockDeleteIDs(client,
+			strings.Split(d.Id(), ",")); err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceNetboxIpamIPBlockDeleteIDs(client *providerClient,
+	ids []string) error {
+	for _, id := range ids {
+		idInt64, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return pkgerrors.New("Unable to convert ID into int64")
+		}
+
+		client.cache.forget(cacheIpamIPAddresses, idInt64)
+
+		resource := ipam.NewIpamIPAddressesDeleteParams().WithID(idInt64)
+		if _, err := client.Ipam.IpamIPAddressesDelete(resource, nil); err != nil {
+			if m, _ := regexp.MatchString("status 404", err.Error()); m {
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
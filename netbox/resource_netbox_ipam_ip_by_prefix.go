@@ -2,17 +2,24 @@ package netbox
 
 import (
 	"errors"
+	"math/big"
+	"net"
 	"regexp"
+	"sort"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	pkgerrors "github.com/pkg/errors"
-	netboxclient "github.com/tomasherout/go-netbox/netbox/client"
 	"github.com/tomasherout/go-netbox/netbox/client/ipam"
 	"github.com/tomasherout/go-netbox/netbox/models"
 )
 
+// Number of times the creation of an address skipping the first or the last
+// addresses of a prefix is retried when another client allocates the same
+// address at the same time.
+const ipByPrefixCreateRetries = 5
+
 func resourceNetboxIpamIPByPrefix() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceNetboxIpamIPByPrefixCreate,
@@ -20,11 +27,21 @@ func resourceNetboxIpamIPByPrefix() *schema.Resource {
 		Update: resourceNetboxIpamIPByPrefixUpdate,
 		Delete: resourceNetboxIpamIPByPrefixsDelete,
 
+		CustomizeDiff: resourceNetboxIpamIPByPrefixCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"address": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"address_family": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"dual_stack"},
+				ValidateFunc:  validation.IntInSlice([]int{4, 6}),
+			},
+			"deletion_policy": deletionPolicySchema(),
 			"description": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -38,10 +55,28 @@ func resourceNetboxIpamIPByPrefix() *schema.Resource {
 					regexp.MustCompile("^[-a-zA-Z0-9_.]{1,255}$"),
 					"Must be like ^[-a-zA-Z0-9_.]{1,255}$"),
 			},
+			"dual_stack": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
 			"interface_id": {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"ipv6_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ipv6_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"ipv6_prefix_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 			"nat_inside_id": {
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -49,6 +84,17 @@ func resourceNetboxIpamIPByPrefix() *schema.Resource {
 			"nat_outside_id": {
 				Type:     schema.TypeInt,
 				Optional: true,
+				Computed: true,
+				Deprecated: "Netbox ignores nat_outside when written, set " +
+					"nat_inside_id on the outside address or use the " +
+					"netbox_ipam_ip_nat resource instead.",
+			},
+			"nat_outside_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
 			},
 			"role": {
 				Type:     schema.TypeString,
@@ -65,13 +111,8 @@ func resourceNetboxIpamIPByPrefix() *schema.Resource {
 				ValidateFunc: validation.StringInSlice([]string{"container", "active",
 					"reserved", "deprecated", "dhcp"}, false),
 			},
-			"tags": {
-				Type: schema.TypeSet,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-				Optional: true,
-			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 			"tenant_id": {
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -81,56 +122,394 @@ func resourceNetboxIpamIPByPrefix() *schema.Resource {
 				Optional: true,
 			},
 			"search_prefix_ids": {
-				Type:     schema.TypeSet,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ForceNew:     true,
+				Deprecated:   "Use prefix_ids to control the order of the prefixes.",
+				ExactlyOneOf: []string{"search_prefix_ids", "prefix_ids"},
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"prefix_ids": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"search_prefix_ids", "prefix_ids"},
 				Elem: &schema.Schema{
 					Type: schema.TypeInt,
 				},
 			},
+			"quarantine_period": quarantinePeriodSchema(),
+			"prefix_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"skip_first": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"skip_last": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"strategy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "first_fit",
+				ValidateFunc: validation.StringInSlice([]string{"first_fit",
+					"most_free", "least_free", "round_robin"}, false),
+			},
 		},
 	}
 }
 
 func resourceNetboxIpamIPByPrefixCreate(d *schema.ResourceData, m interface{}) error {
 
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
-	prefixIds := d.Get("search_prefix_ids").(*schema.Set)
+	var prefixIds []interface{}
+	if v, ok := d.GetOk("prefix_ids"); ok {
+		prefixIds = v.([]interface{})
+	} else {
+		prefixIds = d.Get("search_prefix_ids").(*schema.Set).List()
+	}
 
-	if prefixIds.Len() == 0 {
+	if len(prefixIds) == 0 {
 		return errors.New("search_prefix_ids nemůže být předáno prázdné")
 	}
 
-	// projít jednotlivé prefixy a zkusit v nich získat volnou IP adresu
-	for _, prefixId := range prefixIds.List() {
-		resource := ipam.NewIpamPrefixesAvailableIpsCreateParams().WithID(int64(prefixId.(int)))
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
 
-		// chce: IpamPrefixesAvailableIpsCreateParams
-		resourceCreated, err := client.Ipam.IpamPrefixesAvailableIpsCreate(resource, nil)
+	prefixes, err := resourceNetboxIpamIPByPrefixOrder(client, prefixIds,
+		d.Get("strategy").(string))
+	if err != nil {
+		return err
+	}
 
+	if !d.Get("dual_stack").(bool) {
+		if family := d.Get("address_family").(int); family != 0 {
+			prefixes = ipamPrefixesByFamily(prefixes, family)
+		}
+
+		resourceID, prefixID, err := resourceNetboxIpamIPByPrefixAllocate(d,
+			client, prefixes, tags)
 		if err != nil {
-			// status 204 se vrací v případě, že je subnet plný
-			if m, _ := regexp.MatchString("status 204", err.Error()); m {
-				continue
-			} else {
-				return err
-			}
+			return err
+		}
+
+		if resourceID == 0 {
+			return errors.New("žádný ze subnetů nemá volnou IP adresu")
 		}
 
 		// uložíme si ID
-		// resourceCreated.Payload.ID
-		d.SetId(strconv.FormatInt(resourceCreated.Payload.ID, 10))
+		d.SetId(strconv.FormatInt(resourceID, 10))
+
+		if err = d.Set("prefix_id", prefixID); err != nil {
+			return err
+		}
 
 		// a přečteme IP adresu pro získání zbylých údajů a máme hotovo
 		return resourceNetboxIpamIPByPrefixRead(d, m)
 	}
 
-	return errors.New("žádný ze subnetů nemá volnou IP adresu")
+	// Dual stack: one IPv4 and one IPv6 address sharing the same attributes,
+	// the IPv4 address is the main object of the resource.
+	ipv4ID, ipv4PrefixID, err := resourceNetboxIpamIPByPrefixAllocate(d,
+		client, ipamPrefixesByFamily(prefixes, 4), tags)
+	if err != nil {
+		return err
+	}
+
+	if ipv4ID == 0 {
+		return errors.New("No IPv4 prefix has a free IP address available.")
+	}
+
+	ipv6ID, ipv6PrefixID, err := resourceNetboxIpamIPByPrefixAllocate(d,
+		client, ipamPrefixesByFamily(prefixes, 6), tags)
+	if err == nil && ipv6ID == 0 {
+		err = errors.New("No IPv6 prefix has a free IP address available.")
+	}
+
+	if err != nil {
+		// release the IPv4 address so nothing leaks when the IPv6 allocation
+		// fails
+		resource := ipam.NewIpamIPAddressesDeleteParams().WithID(ipv4ID)
+		if _, errDelete := client.Ipam.IpamIPAddressesDelete(resource,
+			nil); errDelete != nil {
+			return pkgerrors.Wrap(err, errDelete.Error())
+		}
+		return err
+	}
+
+	d.SetId(strconv.FormatInt(ipv4ID, 10))
+
+	if err = d.Set("prefix_id", ipv4PrefixID); err != nil {
+		return err
+	}
+
+	if err = d.Set("ipv6_id", ipv6ID); err != nil {
+		return err
+	}
+
+	if err = d.Set("ipv6_prefix_id", ipv6PrefixID); err != nil {
+		return err
+	}
+
+	return resourceNetboxIpamIPByPrefixRead(d, m)
+}
+
+// resourceNetboxIpamIPByPrefixAllocate creates an IP address in the first
+// prefix with a free address and returns its ID along with the ID of the
+// prefix. Both IDs are 0 when all the prefixes are full.
+func resourceNetboxIpamIPByPrefixAllocate(d *schema.ResourceData,
+	client *providerClient, prefixes []*models.Prefix,
+	tags []*models.NestedTag) (int64, int64, error) {
+	quarantine := d.Get("quarantine_period").(string)
+	skipFirst := d.Get("skip_first").(int)
+	skipLast := d.Get("skip_last").(int)
+	newResource := resourceNetboxIpamIPByPrefixNewIP(d, tags)
+
+	// projít jednotlivé prefixy a zkusit v nich získat volnou IP adresu
+	for _, prefix := range prefixes {
+		if err := ipamPrefixReclaimDeprecatedIPs(client, prefix,
+			quarantine); err != nil {
+			return 0, 0, err
+		}
+
+		if skipFirst == 0 && skipLast == 0 {
+			ips, err := ipamPrefixesAvailableIpsBulkCreate(client, prefix.ID,
+				[]*models.WritableIPAddress{newResource})
+
+			if err != nil {
+				// status 204 se vrací v případě, že je subnet plný
+				if m, _ := regexp.MatchString("status 204", err.Error()); m {
+					continue
+				} else {
+					return 0, 0, err
+				}
+			}
+
+			return ips[0].ID, prefix.ID, nil
+		}
+
+		// The available-ips endpoint always hands out the lowest free
+		// address, the address is picked here and created explicitly.
+		id, err := resourceNetboxIpamIPByPrefixCreateSkipping(client, prefix,
+			newResource, skipFirst, skipLast)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if id == 0 {
+			continue
+		}
+
+		return id, prefix.ID, nil
+	}
+
+	return 0, 0, nil
+}
+
+// resourceNetboxIpamIPByPrefixCreateSkipping creates an IP address with the
+// lowest free address of a prefix ignoring the skipFirst first and skipLast
+// last usable addresses and returns its ID, 0 when no such address is free.
+//
+// Picking the address and creating it are two requests, another client may
+// create the same address in between. The address is looked up again once
+// created and when it is held by several objects, all but the oldest one are
+// deleted by their creators which try the next free address.
+func resourceNetboxIpamIPByPrefixCreateSkipping(client *providerClient,
+	prefix *models.Prefix, newResource *models.WritableIPAddress,
+	skipFirst int, skipLast int) (int64, error) {
+	newResource.Vrf = nil
+	if prefix.Vrf != nil {
+		newResource.Vrf = &prefix.Vrf.ID
+	}
+
+	for attempt := 1; ; attempt++ {
+		address, err := ipamPrefixAvailableIP(client, prefix, skipFirst,
+			skipLast)
+		if err != nil {
+			return 0, err
+		}
+
+		if address == "" {
+			return 0, nil
+		}
+
+		newResource.Address = &address
+
+		resource := ipam.NewIpamIPAddressesCreateParams().WithData(newResource)
+		resourceCreated, err := client.Ipam.IpamIPAddressesCreate(resource, nil)
+		if err != nil {
+			return 0, err
+		}
+
+		id := resourceCreated.Payload.ID
+
+		oldest, err := ipamIPAddressOldestDuplicate(client, address,
+			newResource.Vrf)
+		if err != nil {
+			return 0, err
+		}
+
+		if oldest == 0 || oldest == id {
+			return id, nil
+		}
+
+		params := ipam.NewIpamIPAddressesDeleteParams().WithID(id)
+		if _, err := client.Ipam.IpamIPAddressesDelete(params, nil); err != nil {
+			return 0, err
+		}
+
+		if attempt >= ipByPrefixCreateRetries {
+			return 0, pkgerrors.New("Address " + address + " was allocated " +
+				"by another client at the same time, giving up after " +
+				strconv.Itoa(attempt) + " attempts.")
+		}
+	}
+}
+
+// ipamIPAddressOldestDuplicate returns the lowest ID of the IP address
+// objects holding address in the VRF vrfID (nil for the global table), 0
+// when there is none.
+func ipamIPAddressOldestDuplicate(client *providerClient, address string,
+	vrfID *int64) (int64, error) {
+	host, _, err := net.ParseCIDR(address)
+	if err != nil {
+		return 0, err
+	}
+
+	hostAddress := host.String()
+	params := ipam.NewIpamIPAddressesListParams().WithAddress(&hostAddress)
+	if vrfID != nil {
+		vrf := strconv.FormatInt(*vrfID, 10)
+		params.SetVrfID(&vrf)
+	}
+
+	ips, err := ipamIPAddressesListAll(client, params)
+	if err != nil {
+		return 0, err
+	}
+
+	oldest := int64(0)
+	for _, ip := range ips {
+		// without a VRF filter the other VRFs are listed too
+		if (ip.Vrf == nil) != (vrfID == nil) {
+			continue
+		}
+
+		if oldest == 0 || ip.ID < oldest {
+			oldest = ip.ID
+		}
+	}
+
+	return oldest, nil
+}
+
+// resourceNetboxIpamIPByPrefixNewIP builds the IP address object sent to
+// Netbox from the configuration, the address itself is chosen by the caller.
+func resourceNetboxIpamIPByPrefixNewIP(d *schema.ResourceData,
+	tags []*models.NestedTag) *models.WritableIPAddress {
+	interfaceID := int64(d.Get("interface_id").(int))
+	natInsideID := int64(d.Get("nat_inside_id").(int))
+	tenantID := int64(d.Get("tenant_id").(int))
+
+	newResource := &models.WritableIPAddress{
+		Description: d.Get("description").(string),
+		DNSName:     d.Get("dns_name").(string),
+		Role:        d.Get("role").(string),
+		Status:      d.Get("status").(string),
+		Tags:        tags,
+	}
+
+	if interfaceID != 0 {
+		dcimInterface := "dcim.interface"
+		newResource.AssignedObjectType = &dcimInterface
+		newResource.AssignedObjectID = &interfaceID
+	}
+
+	if natInsideID != 0 {
+		newResource.NatInside = &natInsideID
+	}
+
+	if tenantID != 0 {
+		newResource.Tenant = &tenantID
+	}
+
+	return newResource
+}
+
+// resourceNetboxIpamIPByPrefixOrder reads the prefixes and sorts them in the
+// order they have to be tried according to the allocation strategy.
+func resourceNetboxIpamIPByPrefixOrder(client *providerClient,
+	prefixIds []interface{}, strategy string) ([]*models.Prefix, error) {
+	prefixes := make([]*models.Prefix, len(prefixIds))
+	free := make(map[int64]*big.Int, len(prefixIds))
+
+	for i, prefixID := range prefixIds {
+		params := ipam.NewIpamPrefixesReadParams().WithID(int64(prefixID.(int)))
+		resource, err := client.Ipam.IpamPrefixesRead(params, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		prefixes[i] = resource.Payload
+
+		if strategy != "first_fit" {
+			count, err := ipamPrefixFreeIPCount(client, prefixes[i])
+			if err != nil {
+				return nil, err
+			}
+			free[prefixes[i].ID] = count
+		}
+	}
+
+	switch strategy {
+	case "most_free":
+		sort.SliceStable(prefixes, func(i, j int) bool {
+			return free[prefixes[i].ID].Cmp(free[prefixes[j].ID]) > 0
+		})
+	case "least_free":
+		sort.SliceStable(prefixes, func(i, j int) bool {
+			return free[prefixes[i].ID].Cmp(free[prefixes[j].ID]) < 0
+		})
+	case "round_robin":
+		// The starting prefix moves by one for every address allocated in
+		// any of the prefixes, so consecutive allocations spread evenly.
+		used := new(big.Int)
+		for _, prefix := range prefixes {
+			first, last, err := ipamPrefixUsableRange(prefix)
+			if err != nil {
+				return nil, err
+			}
+
+			size := new(big.Int).Sub(last, first)
+			size.Add(size, big.NewInt(1))
+			used.Add(used, size.Sub(size, free[prefix.ID]))
+		}
+
+		start := int(new(big.Int).Mod(used,
+			big.NewInt(int64(len(prefixes)))).Int64())
+
+		ordered := make([]*models.Prefix, 0, len(prefixes))
+		ordered = append(ordered, prefixes[start:]...)
+		prefixes = append(ordered, prefixes[:start]...)
+	}
+
+	return prefixes, nil
 }
 
 func resourceNetboxIpamIPByPrefixRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
 	ipID := d.Id()
 	ipIDInt64, err := strconv.ParseInt(ipID, 10, 64)
@@ -139,22 +518,16 @@ func resourceNetboxIpamIPByPrefixRead(d *schema.ResourceData, m interface{}) err
 		return err
 	}
 
-	params := ipam.NewIpamIPAddressesReadParams().WithID(ipIDInt64)
-	resource, err := client.Ipam.IpamIPAddressesRead(params, nil)
-
-	// pokud se vrátí chyba
+	payload, err := ipamIPAddressGet(client, ipIDInt64)
 	if err != nil {
-		if m, _ := regexp.MatchString("status 404", err.Error()); m {
-			// chyba je 404
-			// IP adresa neexistuje
-			d.SetId("")
-			return nil
-		} else {
-			return err
-		}
+		return err
 	}
 
-	payload := resource.Payload
+	// IP adresa neexistuje
+	if payload == nil {
+		d.SetId("")
+		return nil
+	}
 
 	if err = d.Set("address", payload.Address); err != nil {
 		return err
@@ -196,6 +569,11 @@ func resourceNetboxIpamIPByPrefixRead(d *schema.ResourceData, m interface{}) err
 		}
 	}
 
+	err = d.Set("nat_outside_ids", flattenIpamIPNatOutside(payload))
+	if err != nil {
+		return err
+	}
+
 	if payload.Role == nil {
 		if err = d.Set("role", nil); err != nil {
 			return err
@@ -216,7 +594,7 @@ func resourceNetboxIpamIPByPrefixRead(d *schema.ResourceData, m interface{}) err
 		}
 	}
 
-	if err = d.Set("tags", payload.Tags); err != nil {
+	if err = setResourceTags(d, client, payload.Tags); err != nil {
 		return err
 	}
 
@@ -240,23 +618,112 @@ func resourceNetboxIpamIPByPrefixRead(d *schema.ResourceData, m interface{}) err
 		}
 	}
 
+	if ipv6ID := int64(d.Get("ipv6_id").(int)); ipv6ID != 0 {
+		ipv6, err := ipamIPAddressGet(client, ipv6ID)
+		if err != nil {
+			return err
+		}
+
+		if ipv6 == nil {
+			// the dual stack pair is broken, only the IPv6 address is
+			// allocated again by the next update
+			return resourceNetboxIpamIPByPrefixClearIPv6(d)
+		}
+
+		if err = d.Set("ipv6_address", ipv6.Address); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// resourceNetboxIpamIPByPrefixClearIPv6 forgets the IPv6 address of a dual
+// stack pair.
+func resourceNetboxIpamIPByPrefixClearIPv6(d *schema.ResourceData) error {
+	if err := d.Set("ipv6_address", ""); err != nil {
+		return err
+	}
+
+	if err := d.Set("ipv6_id", 0); err != nil {
+		return err
+	}
+
+	return d.Set("ipv6_prefix_id", 0)
+}
+
+// resourceNetboxIpamIPByPrefixCustomizeDiff plans the allocation of the IPv6
+// address of a dual stack pair when it is gone, the IPv4 address is kept.
+func resourceNetboxIpamIPByPrefixCustomizeDiff(d *schema.ResourceDiff,
+	m interface{}) error {
+	if err := customizeDiffTagsAll(d, m); err != nil {
+		return err
+	}
+
+	if d.Id() == "" || !d.Get("dual_stack").(bool) ||
+		d.Get("ipv6_id").(int) != 0 {
+		return nil
+	}
+
+	for _, key := range []string{"ipv6_address", "ipv6_id",
+		"ipv6_prefix_id"} {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resourceNetboxIpamIPByPrefixAllocateIPv6 allocates again the IPv6 address
+// of a dual stack pair.
+func resourceNetboxIpamIPByPrefixAllocateIPv6(d *schema.ResourceData,
+	client *providerClient) error {
+	var prefixIds []interface{}
+	if v, ok := d.GetOk("prefix_ids"); ok {
+		prefixIds = v.([]interface{})
+	} else {
+		prefixIds = d.Get("search_prefix_ids").(*schema.Set).List()
+	}
+
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
+	prefixes, err := resourceNetboxIpamIPByPrefixOrder(client, prefixIds,
+		d.Get("strategy").(string))
+	if err != nil {
+		return err
+	}
+
+	ipv6ID, ipv6PrefixID, err := resourceNetboxIpamIPByPrefixAllocate(d,
+		client, ipamPrefixesByFamily(prefixes, 6), tags)
+	if err != nil {
+		return err
+	}
+
+	if ipv6ID == 0 {
+		return errors.New("No IPv6 prefix has a free IP address available.")
+	}
+
+	if err = d.Set("ipv6_id", ipv6ID); err != nil {
+		return err
+	}
+
+	return d.Set("ipv6_prefix_id", ipv6PrefixID)
+}
+
 func resourceNetboxIpamIPByPrefixUpdate(d *schema.ResourceData, m interface{}) error {
 
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 	params := &models.WritableIPAddress{}
 
 	address := d.Get("address").(string)
 	params.Address = &address
 
 	if d.HasChange("description") {
-		if description, exist := d.GetOk("description"); exist {
-			params.Description = description.(string)
-		} else {
-			params.Description = " "
-		}
+		params.Description = d.Get("description").(string)
 	}
 
 	if d.HasChange("dns_name") {
@@ -279,13 +746,6 @@ func resourceNetboxIpamIPByPrefixUpdate(d *schema.ResourceData, m interface{}) e
 		}
 	}
 
-	if d.HasChange("nat_outside_id") {
-		natInsideID := int64(d.Get("nat_outside_id").(int))
-		if natInsideID != 0 {
-			params.NatInside = &natInsideID
-		}
-	}
-
 	if d.HasChange("role") {
 		role := d.Get("role").(string)
 		params.Role = role
@@ -296,8 +756,11 @@ func resourceNetboxIpamIPByPrefixUpdate(d *schema.ResourceData, m interface{}) e
 		params.Status = status
 	}
 
-	tags := d.Get("tags").(*schema.Set).List()
-	params.Tags = expandToStringSlice(tags)
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+	params.Tags = tags
 
 	if d.HasChange("tenant_id") {
 		tenantID := int64(d.Get("tenant_id").(int))
@@ -313,27 +776,54 @@ func resourceNetboxIpamIPByPrefixUpdate(d *schema.ResourceData, m interface{}) e
 		}
 	}
 
-	resource := ipam.NewIpamIPAddressesPartialUpdateParams().WithData(
-		params)
+	patch, err := newNullablePatch(params)
+	if err != nil {
+		return err
+	}
+
+	patch.unset(d, "description", "description")
+	patch.unset(d, "dns_name", "dns_name")
+	patch.unset(d, "interface_id", "assigned_object_type",
+		"assigned_object_id")
+	patch.unset(d, "nat_inside_id", "nat_inside")
+	patch.unset(d, "role", "role")
+	patch.unset(d, "tenant_id", "tenant")
+	patch.unset(d, "vrf_id", "vrf")
 
 	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return pkgerrors.New("Unable to convert ID into int64")
 	}
 
-	resource.SetID(resourceID)
-
-	_, err = client.Ipam.IpamIPAddressesPartialUpdate(resource, nil)
+	err = netboxPartialUpdate(client, "ipam_ip-addresses_partial_update",
+		"/ipam/ip-addresses/{id}/", resourceID, patch)
 	if err != nil {
 		return err
 	}
 
-	return resourceNetboxIpamIPAddressesRead(d, m)
+	if d.Get("dual_stack").(bool) && d.Get("ipv6_id").(int) == 0 {
+		// allocated with the current attributes, nothing to update
+		if err = resourceNetboxIpamIPByPrefixAllocateIPv6(d,
+			client); err != nil {
+			return err
+		}
+	} else if ipv6ID := int64(d.Get("ipv6_id").(int)); ipv6ID != 0 {
+		ipv6Patch := patch.copy()
+		ipv6Patch["address"] = d.Get("ipv6_address").(string)
+
+		err = netboxPartialUpdate(client, "ipam_ip-addresses_partial_update",
+			"/ipam/ip-addresses/{id}/", ipv6ID, ipv6Patch)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceNetboxIpamIPByPrefixRead(d, m)
 }
 
 func resourceNetboxIpamIPByPrefixsDelete(d *schema.ResourceData, m interface{}) error {
 
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
 	ipID := d.Id()
 	ipIDInt64, err := strconv.ParseInt(ipID, 10, 64)
@@ -342,12 +832,47 @@ func resourceNetboxIpamIPByPrefixsDelete(d *schema.ResourceData, m interface{})
 		return err
 	}
 
+	ipv6ID := int64(d.Get("ipv6_id").(int))
+
+	switch d.Get("deletion_policy").(string) {
+	case deletionPolicyRetain:
+		d.SetId("")
+		return nil
+	case deletionPolicyDeprecate:
+		if err := ipamIPAddressDeprecate(client, ipIDInt64,
+			d.Get("address").(string)); err != nil {
+			return err
+		}
+
+		if ipv6ID != 0 {
+			if err := ipamIPAddressDeprecate(client, ipv6ID,
+				d.Get("ipv6_address").(string)); err != nil {
+				return err
+			}
+		}
+
+		d.SetId("")
+		return nil
+	}
+
+	defer client.cache.forget(cacheIpamIPAddresses, ipIDInt64, ipv6ID)
+
 	resource := ipam.NewIpamIPAddressesDeleteParams().WithID(ipIDInt64)
 
 	if _, err := client.Ipam.IpamIPAddressesDelete(resource, nil); err != nil {
 		return err
 	}
 
+	if ipv6ID != 0 {
+		resource := ipam.NewIpamIPAddressesDeleteParams().WithID(ipv6ID)
+
+		if _, err := client.Ipam.IpamIPAddressesDelete(resource, nil); err != nil {
+			if m, _ := regexp.MatchString("status 404", err.Error()); !m {
+				return err
+			}
+		}
+	}
+
 	d.SetId("")
 
 	return nil
@@ -0,0 +1,79 @@
+package netbox
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestIpamIPByPrefixDualStackIPv6Gone(t *testing.T) {
+	// only the IPv4 address of the pair is left
+	client, done := newTestClient(func(w http.ResponseWriter,
+		r *http.Request) {
+		if r.URL.Path != "/api/ipam/ip-addresses/1/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":      1,
+			"address": "10.0.0.1/24",
+			"tags":    []interface{}{},
+		})
+	})
+	defer done()
+
+	d := schema.TestResourceDataRaw(t, resourceNetboxIpamIPByPrefix().Schema,
+		map[string]interface{}{
+			"dual_stack": true,
+			"prefix_ids": []interface{}{10, 20},
+		})
+	d.SetId("1")
+	for key, value := range map[string]interface{}{
+		"ipv6_address":   "2001:db8::1/64",
+		"ipv6_id":        2,
+		"ipv6_prefix_id": 20,
+	} {
+		if err := d.Set(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := resourceNetboxIpamIPByPrefixRead(d, client); err != nil {
+		t.Fatal(err)
+	}
+
+	// the IPv4 address stays managed
+	if d.Id() != "1" {
+		t.Fatalf("the resource is gone from the state")
+	}
+
+	if d.Get("ipv6_id").(int) != 0 || d.Get("ipv6_address").(string) != "" {
+		t.Errorf("the IPv6 address is still in the state: %d %s",
+			d.Get("ipv6_id").(int), d.Get("ipv6_address").(string))
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"dual_stack": true,
+		"prefix_ids": []interface{}{10, 20},
+	})
+
+	diff, err := resourceNetboxIpamIPByPrefix().Diff(d.State(), config,
+		client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff.Empty() || diff.RequiresNew() {
+		t.Fatalf("expected an update allocating the IPv6 address, got %v",
+			diff)
+	}
+
+	if attr, ok := diff.Attributes["ipv6_id"]; !ok || !attr.NewComputed {
+		t.Errorf("ipv6_id is not planned to be allocated: %v", diff)
+	}
+}
@@ -0,0 +1,180 @@
+package netbox
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+// The NAT relationship is stored on the outside address, which points to its
+// inside address with nat_inside. Netbox computes nat_outside from it and
+// ignores it when written, an inside address has at most one outside
+// address.
+func resourceNetboxIpamIPNat() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetboxIpamIPNatCreate,
+		Read:   resourceNetboxIpamIPNatRead,
+		Delete: resourceNetboxIpamIPNatDelete,
+
+		Schema: map[string]*schema.Schema{
+			"inside_ip_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"outside_ip_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceNetboxIpamIPNatCreate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	insideID := int64(d.Get("inside_ip_id").(int))
+	outsideID := int64(d.Get("outside_ip_id").(int))
+
+	inside, err := ipamIPAddressGet(client, insideID)
+	if err != nil {
+		return err
+	}
+
+	outside, err := ipamIPAddressGet(client, outsideID)
+	if err != nil {
+		return err
+	}
+
+	if err := ipamIPNatCheck(inside, outside); err != nil {
+		return err
+	}
+
+	// nat_outside of the inside address changes as well
+	defer client.cache.forget(cacheIpamIPAddresses, insideID)
+
+	patch := nullablePatch{"nat_inside": insideID}
+	err = netboxPartialUpdate(client, "ipam_ip-addresses_partial_update",
+		"/ipam/ip-addresses/{id}/", outsideID, patch)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.FormatInt(outsideID, 10))
+	return resourceNetboxIpamIPNatRead(d, m)
+}
+
+// ipamIPNatCheck returns an error when two addresses can not be the inside
+// and the outside of a NAT: they must belong to the same address family, must
+// not be the same address of a VRF, the global table being handled like a
+// VRF, and must not be translated already.
+func ipamIPNatCheck(inside *models.IPAddress,
+	outside *models.IPAddress) error {
+	if inside == nil || outside == nil {
+		return pkgerrors.New("The inside and the outside IP addresses must " +
+			"exist in Netbox.")
+	}
+
+	if inside.ID == outside.ID {
+		return pkgerrors.New("An IP address can not be the inside and the " +
+			"outside of the same NAT.")
+	}
+
+	if inside.Family != nil && outside.Family != nil &&
+		inside.Family.Value != nil && outside.Family.Value != nil &&
+		*inside.Family.Value != *outside.Family.Value {
+		return pkgerrors.New("The inside " + stringValue(inside.Address) +
+			" and the outside " + stringValue(outside.Address) +
+			" IP addresses are not of the same family.")
+	}
+
+	if ipamIPAddressVrfID(inside) == ipamIPAddressVrfID(outside) &&
+		stringValue(inside.Address) == stringValue(outside.Address) {
+		return pkgerrors.New("The inside and the outside IP addresses are " +
+			"the same address " + stringValue(inside.Address) + " of the " +
+			"same VRF.")
+	}
+
+	if inside.NatOutside != nil && inside.NatOutside.ID != outside.ID {
+		return pkgerrors.New("The inside IP address " +
+			stringValue(inside.Address) + " is already translated to " +
+			stringValue(inside.NatOutside.Address) + ".")
+	}
+
+	if outside.NatInside != nil && outside.NatInside.ID != inside.ID {
+		return pkgerrors.New("The outside IP address " +
+			stringValue(outside.Address) + " already translates " +
+			stringValue(outside.NatInside.Address) + ".")
+	}
+
+	return nil
+}
+
+func resourceNetboxIpamIPNatRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	outsideID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	outside, err := ipamIPAddressGet(client, outsideID)
+	if err != nil {
+		return err
+	}
+
+	// the NAT is gone along with the outside address or when nat_inside was
+	// cleared
+	if outside == nil || outside.NatInside == nil {
+		d.SetId("")
+		return nil
+	}
+
+	if err = d.Set("inside_ip_id", outside.NatInside.ID); err != nil {
+		return err
+	}
+
+	return d.Set("outside_ip_id", outside.ID)
+}
+
+func resourceNetboxIpamIPNatDelete(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	outsideID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	insideID := int64(d.Get("inside_ip_id").(int))
+	defer client.cache.forget(cacheIpamIPAddresses, insideID)
+
+	patch := nullablePatch{"nat_inside": nil}
+	err = netboxPartialUpdate(client, "ipam_ip-addresses_partial_update",
+		"/ipam/ip-addresses/{id}/", outsideID, patch)
+	if err != nil {
+		// the outside address is gone along with the NAT
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ipamIPAddressVrfID returns the ID of the VRF of an IP address, 0 for the
+// global table.
+func ipamIPAddressVrfID(ip *models.IPAddress) int64 {
+	if ip.Vrf == nil {
+		return 0
+	}
+
+	return ip.Vrf.ID
+}
@@ -0,0 +1,91 @@
+package netbox
+
+import (
+	"testing"
+
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+func testNatIP(id int64, address string, vrfID int64) *models.IPAddress {
+	family := int64(4)
+	ip := &models.IPAddress{
+		ID:      id,
+		Address: &address,
+		Family:  &models.IPAddressFamily{Value: &family},
+	}
+
+	if vrfID != 0 {
+		name := "vrf-" + address
+		ip.Vrf = &models.NestedVRF{ID: vrfID, Name: &name}
+	}
+
+	return ip
+}
+
+func TestIpamIPNatCheck(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		inside  *models.IPAddress
+		outside *models.IPAddress
+		valid   bool
+	}{
+		{
+			name:    "global table",
+			inside:  testNatIP(1, "10.0.0.1/24", 0),
+			outside: testNatIP(2, "198.51.100.1/24", 0),
+			valid:   true,
+		},
+		{
+			name:    "VRF to the global table",
+			inside:  testNatIP(1, "10.0.0.1/24", 7),
+			outside: testNatIP(2, "198.51.100.1/24", 0),
+			valid:   true,
+		},
+		{
+			name:    "global table to a VRF",
+			inside:  testNatIP(1, "10.0.0.1/24", 0),
+			outside: testNatIP(2, "198.51.100.1/24", 7),
+			valid:   true,
+		},
+		{
+			name:    "different VRFs",
+			inside:  testNatIP(1, "10.0.0.1/24", 7),
+			outside: testNatIP(2, "10.0.0.1/24", 8),
+			valid:   true,
+		},
+		// private to public within a VRF like in the global table
+		{
+			name:    "same VRF",
+			inside:  testNatIP(1, "10.0.0.1/24", 7),
+			outside: testNatIP(2, "198.51.100.1/24", 7),
+			valid:   true,
+		},
+		{
+			name:    "same address of a VRF",
+			inside:  testNatIP(1, "10.0.0.1/24", 7),
+			outside: testNatIP(2, "10.0.0.1/24", 7),
+		},
+		{
+			name:    "same address of the global table",
+			inside:  testNatIP(1, "10.0.0.1/24", 0),
+			outside: testNatIP(2, "10.0.0.1/24", 0),
+		},
+		{
+			name:    "same object",
+			inside:  testNatIP(1, "10.0.0.1/24", 0),
+			outside: testNatIP(1, "10.0.0.1/24", 0),
+		},
+		{
+			name:   "missing address",
+			inside: testNatIP(1, "10.0.0.1/24", 0),
+		},
+	} {
+		err := ipamIPNatCheck(test.inside, test.outside)
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
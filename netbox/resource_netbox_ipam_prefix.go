@@ -1,12 +1,12 @@
 package netbox
 
 import (
+	"regexp"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	pkgerrors "github.com/pkg/errors"
-	netboxclient "github.com/tomasherout/go-netbox/netbox/client"
 	"github.com/tomasherout/go-netbox/netbox/client/ipam"
 	"github.com/tomasherout/go-netbox/netbox/models"
 )
@@ -17,9 +17,11 @@ func resourceNetboxIpamPrefix() *schema.Resource {
 		Read:   resourceNetboxIpamPrefixRead,
 		Update: resourceNetboxIpamPrefixUpdate,
 		Delete: resourceNetboxIpamPrefixDelete,
-		Exists: resourceNetboxIpamPrefixExists,
+
+		CustomizeDiff: customizeDiffTagsAll,
 
 		Schema: map[string]*schema.Schema{
+			"deletion_policy": deletionPolicySchema(),
 			"description": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -50,13 +52,8 @@ func resourceNetboxIpamPrefix() *schema.Resource {
 				ValidateFunc: validation.StringInSlice([]string{"container", "active",
 					"reserved", "deprecated"}, false),
 			},
-			"tags": {
-				Type: schema.TypeSet,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-				Optional: true,
-			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 			"tenant_id": {
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -75,7 +72,7 @@ func resourceNetboxIpamPrefix() *schema.Resource {
 
 func resourceNetboxIpamPrefixCreate(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
 	description := d.Get("description").(string)
 	isPool := d.Get("is_pool").(bool)
@@ -83,17 +80,21 @@ func resourceNetboxIpamPrefixCreate(d *schema.ResourceData,
 	roleID := int64(d.Get("role_id").(int))
 	siteID := int64(d.Get("site_id").(int))
 	status := d.Get("status").(string)
-	tags := d.Get("tags").(*schema.Set).List()
 	tenantID := int64(d.Get("tenant_id").(int))
 	vlanID := int64(d.Get("vlan_id").(int))
 	vrfID := int64(d.Get("vrf_id").(int))
 
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
 	newResource := &models.WritablePrefix{
 		Description: description,
 		IsPool:      isPool,
 		Prefix:      &prefix,
 		Status:      status,
-		Tags:        expandToStringSlice(tags),
+		Tags:        tags,
 	}
 
 	if roleID != 0 {
@@ -130,104 +131,105 @@ func resourceNetboxIpamPrefixCreate(d *schema.ResourceData,
 
 func resourceNetboxIpamPrefixRead(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
-	resourceID := d.Id()
-	params := ipam.NewIpamPrefixesListParams().WithID(&resourceID)
-	resources, err := client.Ipam.IpamPrefixesList(params, nil)
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	resource, err := ipamPrefixGet(client, resourceID)
 	if err != nil {
 		return err
 	}
 
-	for _, resource := range resources.Payload.Results {
-		if strconv.FormatInt(resource.ID, 10) == d.Id() {
-			if err = d.Set("description", resource.Description); err != nil {
-				return err
-			}
+	if resource == nil {
+		d.SetId("")
+		return nil
+	}
 
-			if err = d.Set("is_pool", resource.IsPool); err != nil {
-				return err
-			}
+	if err = d.Set("description", resource.Description); err != nil {
+		return err
+	}
 
-			if err = d.Set("prefix", resource.Prefix); err != nil {
-				return err
-			}
+	if err = d.Set("is_pool", resource.IsPool); err != nil {
+		return err
+	}
 
-			if resource.Role == nil {
-				if err = d.Set("role_id", nil); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("role_id", resource.Role.ID); err != nil {
-					return err
-				}
-			}
+	if err = d.Set("prefix", resource.Prefix); err != nil {
+		return err
+	}
 
-			if resource.Site == nil {
-				if err = d.Set("site_id", nil); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("site_id", resource.Site.ID); err != nil {
-					return err
-				}
-			}
+	if resource.Role == nil {
+		if err = d.Set("role_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("role_id", resource.Role.ID); err != nil {
+			return err
+		}
+	}
 
-			if resource.Status == nil {
-				if err = d.Set("status", nil); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("status", resource.Status.Value); err != nil {
-					return err
-				}
-			}
+	if resource.Site == nil {
+		if err = d.Set("site_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("site_id", resource.Site.ID); err != nil {
+			return err
+		}
+	}
 
-			if err = d.Set("tags", resource.Tags); err != nil {
-				return err
-			}
+	if resource.Status == nil {
+		if err = d.Set("status", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("status", resource.Status.Value); err != nil {
+			return err
+		}
+	}
 
-			if resource.Tenant == nil {
-				if err = d.Set("tenant_id", nil); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("tenant_id", resource.Tenant.ID); err != nil {
-					return err
-				}
-			}
+	if err = setResourceTags(d, client, resource.Tags); err != nil {
+		return err
+	}
 
-			if resource.Vlan == nil {
-				if err = d.Set("vlan_id", nil); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("vlan_id", resource.Vlan.ID); err != nil {
-					return err
-				}
-			}
+	if resource.Tenant == nil {
+		if err = d.Set("tenant_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("tenant_id", resource.Tenant.ID); err != nil {
+			return err
+		}
+	}
 
-			if resource.Vrf == nil {
-				if err = d.Set("vrf_id", nil); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("vrf_id", resource.Vrf.ID); err != nil {
-					return err
-				}
-			}
+	if resource.Vlan == nil {
+		if err = d.Set("vlan_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("vlan_id", resource.Vlan.ID); err != nil {
+			return err
+		}
+	}
 
-			return nil
+	if resource.Vrf == nil {
+		if err = d.Set("vrf_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("vrf_id", resource.Vrf.ID); err != nil {
+			return err
 		}
 	}
 
-	d.SetId("")
 	return nil
 }
 
 func resourceNetboxIpamPrefixUpdate(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 	params := &models.WritablePrefix{}
 
 	if d.HasChange("description") {
@@ -258,8 +260,11 @@ func resourceNetboxIpamPrefixUpdate(d *schema.ResourceData,
 		params.Status = d.Get("status").(string)
 	}
 
-	tags := d.Get("tags").(*schema.Set).List()
-	params.Tags = expandToStringSlice(tags)
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+	params.Tags = tags
 
 	if d.HasChange("tenant_id") {
 		tenantID := int64(d.Get("tenant_id").(int))
@@ -282,16 +287,26 @@ func resourceNetboxIpamPrefixUpdate(d *schema.ResourceData,
 		}
 	}
 
-	resource := ipam.NewIpamPrefixesPartialUpdateParams().WithData(params)
+	patch, err := newNullablePatch(params)
+	if err != nil {
+		return err
+	}
+
+	patch.unset(d, "description", "description")
+	patch.unset(d, "is_pool", "is_pool")
+	patch.unset(d, "role_id", "role")
+	patch.unset(d, "site_id", "site")
+	patch.unset(d, "tenant_id", "tenant")
+	patch.unset(d, "vlan_id", "vlan")
+	patch.unset(d, "vrf_id", "vrf")
 
 	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return pkgerrors.New("Unable to convert ID into int64")
 	}
 
-	resource.SetID(resourceID)
-
-	_, err = client.Ipam.IpamPrefixesPartialUpdate(resource, nil)
+	err = netboxPartialUpdate(client, "ipam_prefixes_partial_update",
+		"/ipam/prefixes/{id}/", resourceID, patch)
 	if err != nil {
 		return err
 	}
@@ -301,47 +316,53 @@ func resourceNetboxIpamPrefixUpdate(d *schema.ResourceData,
 
 func resourceNetboxIpamPrefixDelete(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
-
-	resourceExists, err := resourceNetboxIpamPrefixExists(d, m)
-	if err != nil {
-		return err
-	}
-
-	if !resourceExists {
-		return nil
-	}
+	client := m.(*providerClient)
 
 	id, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return pkgerrors.New("Unable to convert ID into int64")
 	}
 
-	resource := ipam.NewIpamPrefixesDeleteParams().WithID(id)
-	if _, err := client.Ipam.IpamPrefixesDelete(resource, nil); err != nil {
-		return err
-	}
+	defer client.cache.forget(cacheIpamPrefixes, id)
 
-	return nil
-}
+	switch d.Get("deletion_policy").(string) {
+	case deletionPolicyRetain:
+		return nil
+	case deletionPolicyDeprecate:
+		prefix := d.Get("prefix").(string)
+		tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+		if err != nil {
+			return err
+		}
+
+		params := &models.WritablePrefix{
+			Description: deprecatedDescription(),
+			Prefix:      &prefix,
+			Status:      "deprecated",
+			Tags:        tags,
+		}
 
-func resourceNetboxIpamPrefixExists(d *schema.ResourceData,
-	m interface{}) (b bool, e error) {
-	client := m.(*netboxclient.NetBoxAPI)
-	resourceExist := false
+		resource := ipam.NewIpamPrefixesPartialUpdateParams().WithData(params)
+		resource.SetID(id)
 
-	resourceID := d.Id()
-	params := ipam.NewIpamPrefixesListParams().WithID(&resourceID)
-	resources, err := client.Ipam.IpamPrefixesList(params, nil)
-	if err != nil {
-		return resourceExist, err
+		_, err = client.Ipam.IpamPrefixesPartialUpdate(resource, nil)
+		if err != nil {
+			// already deleted outside of terraform
+			if m, _ := regexp.MatchString("status 404", err.Error()); m {
+				return nil
+			}
+		}
+		return err
 	}
 
-	for _, resource := range resources.Payload.Results {
-		if strconv.FormatInt(resource.ID, 10) == d.Id() {
-			resourceExist = true
+	resource := ipam.NewIpamPrefixesDeleteParams().WithID(id)
+	if _, err := client.Ipam.IpamPrefixesDelete(resource, nil); err != nil {
+		// already deleted outside of terraform
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			return nil
 		}
+		return err
 	}
 
-	return resourceExist, nil
+	return nil
 }
@@ -0,0 +1,190 @@
+package netbox
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/ipam"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+func resourceNetboxIpamPrefixByParent() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetboxIpamPrefixByParentCreate,
+		Read:   resourceNetboxIpamPrefixRead,
+		Update: resourceNetboxIpamPrefixUpdate,
+		Delete: resourceNetboxIpamPrefixDelete,
+
+		CustomizeDiff: customizeDiffTagsAll,
+
+		Schema: map[string]*schema.Schema{
+			"deletion_policy": deletionPolicySchema(),
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 100),
+			},
+			"is_pool": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  nil,
+			},
+			"parent_prefix_ids": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"prefix": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"prefix_length": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(1, 128),
+			},
+			"role_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"site_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "active",
+				ValidateFunc: validation.StringInSlice([]string{"container", "active",
+					"reserved", "deprecated"}, false),
+			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
+			"tenant_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"vlan_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"vrf_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNetboxIpamPrefixByParentCreate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	parentIDs := d.Get("parent_prefix_ids").([]interface{})
+	prefixLength := int64(d.Get("prefix_length").(int))
+
+	// resolved before allocating, the tags are only written afterwards
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
+	// Parents are tried in the configured order, the first one with enough
+	// free space wins.
+	for _, parentID := range parentIDs {
+		resource := ipam.NewIpamPrefixesAvailablePrefixesCreateParams().WithID(
+			int64(parentID.(int))).WithData(&models.PrefixLength{
+			PrefixLength: &prefixLength,
+		})
+
+		resourceCreated, err := client.Ipam.IpamPrefixesAvailablePrefixesCreate(
+			resource, nil)
+		if err != nil {
+			// Netbox answers with status 204 when the parent is full
+			if m, _ := regexp.MatchString("status 204", err.Error()); m {
+				continue
+			}
+			return err
+		}
+
+		d.SetId(strconv.FormatInt(resourceCreated.Payload.ID, 10))
+
+		return resourceNetboxIpamPrefixByParentSetAttributes(d, m,
+			*resourceCreated.Payload.Prefix, tags)
+	}
+
+	return pkgerrors.New("None of the parent prefixes has a free /" +
+		strconv.FormatInt(prefixLength, 10) + " prefix available.")
+}
+
+// The available-prefixes endpoint only accepts the prefix length, so the
+// remaining attributes are written to the new prefix afterwards.
+func resourceNetboxIpamPrefixByParentSetAttributes(d *schema.ResourceData,
+	m interface{}, prefix string, tags []*models.NestedTag) error {
+	client := m.(*providerClient)
+
+	description := d.Get("description").(string)
+	isPool := d.Get("is_pool").(bool)
+	roleID := int64(d.Get("role_id").(int))
+	siteID := int64(d.Get("site_id").(int))
+	status := d.Get("status").(string)
+	tenantID := int64(d.Get("tenant_id").(int))
+	vlanID := int64(d.Get("vlan_id").(int))
+	vrfID := int64(d.Get("vrf_id").(int))
+
+	params := &models.WritablePrefix{
+		Description: description,
+		IsPool:      isPool,
+		Prefix:      &prefix,
+		Status:      status,
+		Tags:        tags,
+	}
+
+	if roleID != 0 {
+		params.Role = &roleID
+	}
+
+	if siteID != 0 {
+		params.Site = &siteID
+	}
+
+	if tenantID != 0 {
+		params.Tenant = &tenantID
+	}
+
+	if vlanID != 0 {
+		params.Vlan = &vlanID
+	}
+
+	if vrfID != 0 {
+		params.Vrf = &vrfID
+	}
+
+	resource := ipam.NewIpamPrefixesPartialUpdateParams().WithData(params)
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	resource.SetID(resourceID)
+
+	defer client.cache.forget(cacheIpamPrefixes, resourceID)
+
+	_, err = client.Ipam.IpamPrefixesPartialUpdate(resource, nil)
+	if err != nil {
+		return err
+	}
+
+	return resourceNetboxIpamPrefixRead(d, m)
+}
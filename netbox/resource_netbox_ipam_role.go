@@ -0,0 +1,176 @@
+package netbox
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/ipam"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+func resourceNetboxIpamRole() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetboxIpamRoleCreate,
+		Read:   resourceNetboxIpamRoleRead,
+		Update: resourceNetboxIpamRoleUpdate,
+		Delete: resourceNetboxIpamRoleDelete,
+
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 200),
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 50),
+			},
+			"slug": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile("^[-a-zA-Z0-9_]{1,50}$"),
+					"Must be like ^[-a-zA-Z0-9_]{1,50}$"),
+			},
+			"weight": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntBetween(0, 32767),
+			},
+		},
+	}
+}
+
+func resourceNetboxIpamRoleCreate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	description := d.Get("description").(string)
+	name := d.Get("name").(string)
+	slug := d.Get("slug").(string)
+	weight := int64(d.Get("weight").(int))
+
+	newResource := &models.Role{
+		Description: description,
+		Name:        &name,
+		Slug:        &slug,
+		Weight:      &weight,
+	}
+
+	resource := ipam.NewIpamRolesCreateParams().WithData(newResource)
+
+	resourceCreated, err := client.Ipam.IpamRolesCreate(resource, nil)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.FormatInt(resourceCreated.Payload.ID, 10))
+	return resourceNetboxIpamRoleRead(d, m)
+}
+
+func resourceNetboxIpamRoleRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	params := ipam.NewIpamRolesReadParams().WithID(resourceID)
+	response, err := client.Ipam.IpamRolesRead(params, nil)
+	if err != nil {
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	resource := response.Payload
+
+	if err = d.Set("description", resource.Description); err != nil {
+		return err
+	}
+
+	if err = d.Set("name", resource.Name); err != nil {
+		return err
+	}
+
+	if err = d.Set("slug", resource.Slug); err != nil {
+		return err
+	}
+
+	if err = d.Set("weight", resource.Weight); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceNetboxIpamRoleUpdate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+	params := &models.Role{}
+
+	if d.HasChange("description") {
+		description := d.Get("description").(string)
+		params.Description = description
+	}
+
+	name := d.Get("name").(string)
+	params.Name = &name
+
+	slug := d.Get("slug").(string)
+	params.Slug = &slug
+
+	if d.HasChange("weight") {
+		weight := int64(d.Get("weight").(int))
+		params.Weight = &weight
+	}
+
+	patch, err := newNullablePatch(params)
+	if err != nil {
+		return err
+	}
+
+	patch.unset(d, "description", "description")
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	err = netboxPartialUpdate(client, "ipam_roles_partial_update",
+		"/ipam/roles/{id}/", resourceID, patch)
+	if err != nil {
+		return err
+	}
+
+	return resourceNetboxIpamRoleRead(d, m)
+}
+
+func resourceNetboxIpamRoleDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerClient)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	resource := ipam.NewIpamRolesDeleteParams().WithID(id)
+	if _, err := client.Ipam.IpamRolesDelete(resource, nil); err != nil {
+		// already deleted outside of terraform
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
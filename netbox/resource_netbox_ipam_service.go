@@ -0,0 +1,307 @@
+package netbox
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/ipam"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+// A service listens on a device or on a virtual machine, never on both.
+// Netbox 2.9 stores a single port per service, ports is a list so a service
+// listening on several ports does not need a new attribute once Netbox
+// accepts them.
+func resourceNetboxIpamService() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetboxIpamServiceCreate,
+		Read:   resourceNetboxIpamServiceRead,
+		Update: resourceNetboxIpamServiceUpdate,
+		Delete: resourceNetboxIpamServiceDelete,
+
+		CustomizeDiff: customizeDiffTagsAll,
+
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 200),
+			},
+			"device_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ExactlyOneOf: []string{"device_id", "virtual_machine_id"},
+			},
+			"ip_address_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 30),
+			},
+			"ports": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				MaxItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(1, 65535),
+				},
+			},
+			"protocol": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{"tcp", "udp"},
+					false),
+			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
+			"virtual_machine_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ExactlyOneOf: []string{"device_id", "virtual_machine_id"},
+			},
+		},
+	}
+}
+
+// resourceNetboxIpamServiceIPAddresses returns the IDs of the IP addresses
+// the service is bound to, an empty list and never nil so the binding is
+// removed from Netbox as well.
+func resourceNetboxIpamServiceIPAddresses(d *schema.ResourceData) []int64 {
+	ids := d.Get("ip_address_ids").(*schema.Set).List()
+
+	ipAddresses := make([]int64, len(ids))
+	for i, id := range ids {
+		ipAddresses[i] = int64(id.(int))
+	}
+
+	return ipAddresses
+}
+
+func resourceNetboxIpamServiceCreate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	description := d.Get("description").(string)
+	deviceID := int64(d.Get("device_id").(int))
+	name := d.Get("name").(string)
+	port := int64(d.Get("ports").([]interface{})[0].(int))
+	protocol := d.Get("protocol").(string)
+	virtualMachineID := int64(d.Get("virtual_machine_id").(int))
+
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
+	newResource := &models.WritableService{
+		Description: description,
+		Ipaddresses: resourceNetboxIpamServiceIPAddresses(d),
+		Name:        &name,
+		Port:        &port,
+		Protocol:    &protocol,
+		Tags:        tags,
+	}
+
+	if deviceID != 0 {
+		newResource.Device = &deviceID
+	}
+
+	if virtualMachineID != 0 {
+		newResource.VirtualMachine = &virtualMachineID
+	}
+
+	resource := ipam.NewIpamServicesCreateParams().WithData(newResource)
+
+	resourceCreated, err := client.Ipam.IpamServicesCreate(resource, nil)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.FormatInt(resourceCreated.Payload.ID, 10))
+
+	return resourceNetboxIpamServiceRead(d, m)
+}
+
+func resourceNetboxIpamServiceRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	params := ipam.NewIpamServicesReadParams().WithID(resourceID)
+	response, err := client.Ipam.IpamServicesRead(params, nil)
+	if err != nil {
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	resource := response.Payload
+
+	if err = d.Set("description", resource.Description); err != nil {
+		return err
+	}
+
+	if resource.Device == nil {
+		if err = d.Set("device_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("device_id", resource.Device.ID); err != nil {
+			return err
+		}
+	}
+
+	ipAddresses := make([]int64, 0, len(resource.Ipaddresses))
+	for _, ip := range resource.Ipaddresses {
+		ipAddresses = append(ipAddresses, ip.ID)
+	}
+
+	if err = d.Set("ip_address_ids", ipAddresses); err != nil {
+		return err
+	}
+
+	if err = d.Set("name", resource.Name); err != nil {
+		return err
+	}
+
+	if resource.Port == nil {
+		if err = d.Set("ports", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("ports", []int64{*resource.Port}); err != nil {
+			return err
+		}
+	}
+
+	if resource.Protocol == nil {
+		if err = d.Set("protocol", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("protocol", resource.Protocol.Value); err != nil {
+			return err
+		}
+	}
+
+	if err = setResourceTags(d, client, resource.Tags); err != nil {
+		return err
+	}
+
+	if resource.VirtualMachine == nil {
+		if err = d.Set("virtual_machine_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("virtual_machine_id",
+			resource.VirtualMachine.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceNetboxIpamServiceUpdate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+	params := &models.WritableService{}
+
+	if d.HasChange("description") {
+		params.Description = d.Get("description").(string)
+	}
+
+	if d.HasChange("device_id") {
+		deviceID := int64(d.Get("device_id").(int))
+		if deviceID != 0 {
+			params.Device = &deviceID
+		}
+	}
+
+	params.Ipaddresses = resourceNetboxIpamServiceIPAddresses(d)
+
+	name := d.Get("name").(string)
+	params.Name = &name
+
+	port := int64(d.Get("ports").([]interface{})[0].(int))
+	params.Port = &port
+
+	protocol := d.Get("protocol").(string)
+	params.Protocol = &protocol
+
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+	params.Tags = tags
+
+	if d.HasChange("virtual_machine_id") {
+		virtualMachineID := int64(d.Get("virtual_machine_id").(int))
+		if virtualMachineID != 0 {
+			params.VirtualMachine = &virtualMachineID
+		}
+	}
+
+	patch, err := newNullablePatch(params)
+	if err != nil {
+		return err
+	}
+
+	patch.unset(d, "description", "description")
+
+	// moving the service from a device to a virtual machine or back clears
+	// the former host
+	patch.unset(d, "device_id", "device")
+	patch.unset(d, "virtual_machine_id", "virtual_machine")
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	err = netboxPartialUpdate(client, "ipam_services_partial_update",
+		"/ipam/services/{id}/", resourceID, patch)
+	if err != nil {
+		return err
+	}
+
+	return resourceNetboxIpamServiceRead(d, m)
+}
+
+func resourceNetboxIpamServiceDelete(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	p := ipam.NewIpamServicesDeleteParams().WithID(id)
+	if _, err := client.Ipam.IpamServicesDelete(p, nil); err != nil {
+		// already deleted outside of terraform, e.g. along with its host
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
@@ -1,12 +1,12 @@
 package netbox
 
 import (
+	"regexp"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	pkgerrors "github.com/pkg/errors"
-	netboxclient "github.com/tomasherout/go-netbox/netbox/client"
 	"github.com/tomasherout/go-netbox/netbox/client/ipam"
 	"github.com/tomasherout/go-netbox/netbox/models"
 )
@@ -17,9 +17,11 @@ func resourceNetboxIpamVlan() *schema.Resource {
 		Read:   resourceNetboxIpamVlanRead,
 		Update: resourceNetboxIpamVlanUpdate,
 		Delete: resourceNetboxIpamVlanDelete,
-		Exists: resourceNetboxIpamVlanExists,
+
+		CustomizeDiff: customizeDiffTagsAll,
 
 		Schema: map[string]*schema.Schema{
+			"deletion_policy": deletionPolicySchema(),
 			"description": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -49,13 +51,8 @@ func resourceNetboxIpamVlan() *schema.Resource {
 				ValidateFunc: validation.StringInSlice([]string{"active", "reserved",
 					"deprecated"}, false),
 			},
-			"tags": {
-				Type: schema.TypeSet,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-				Optional: true,
-			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 			"tenant_id": {
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -70,7 +67,7 @@ func resourceNetboxIpamVlan() *schema.Resource {
 
 func resourceNetboxIpamVlanCreate(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
 	description := d.Get("description").(string)
 	groupID := int64(d.Get("vlan_group_id").(int))
@@ -78,15 +75,19 @@ func resourceNetboxIpamVlanCreate(d *schema.ResourceData,
 	roleID := int64(d.Get("role_id").(int))
 	siteID := int64(d.Get("site_id").(int))
 	status := d.Get("status").(string)
-	tags := d.Get("tags").(*schema.Set).List()
 	tenantID := int64(d.Get("tenant_id").(int))
 	vid := int64(d.Get("vlan_id").(int))
 
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
 	newResource := &models.WritableVLAN{
 		Description: description,
 		Name:        &name,
 		Status:      status,
-		Tags:        expandToStringSlice(tags),
+		Tags:        tags,
 		Vid:         &vid,
 	}
 
@@ -119,94 +120,95 @@ func resourceNetboxIpamVlanCreate(d *schema.ResourceData,
 
 func resourceNetboxIpamVlanRead(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
-	resourceID := d.Id()
-	params := ipam.NewIpamVlansListParams().WithID(&resourceID)
-	resources, err := client.Ipam.IpamVlansList(params, nil)
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return err
+		return pkgerrors.New("Unable to convert ID into int64")
 	}
 
-	for _, resource := range resources.Payload.Results {
-		if strconv.FormatInt(resource.ID, 10) == d.Id() {
-			if err = d.Set("description", resource.Description); err != nil {
-				return err
-			}
+	resource, err := ipamVlanGet(client, resourceID)
+	if err != nil {
+		return err
+	}
 
-			if resource.Group == nil {
-				if err = d.Set("vlan_group_id", nil); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("vlan_group_id", resource.Group.ID); err != nil {
-					return err
-				}
-			}
+	if resource == nil {
+		d.SetId("")
+		return nil
+	}
 
-			if err = d.Set("name", resource.Name); err != nil {
-				return err
-			}
+	if err = d.Set("description", resource.Description); err != nil {
+		return err
+	}
 
-			if resource.Role == nil {
-				if err = d.Set("role_id", nil); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("role_id", resource.Role.ID); err != nil {
-					return err
-				}
-			}
+	if resource.Group == nil {
+		if err = d.Set("vlan_group_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("vlan_group_id", resource.Group.ID); err != nil {
+			return err
+		}
+	}
 
-			if resource.Site == nil {
-				if err = d.Set("site_id", nil); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("site_id", resource.Site.ID); err != nil {
-					return err
-				}
-			}
+	if err = d.Set("name", resource.Name); err != nil {
+		return err
+	}
 
-			if resource.Status == nil {
-				if err = d.Set("status", nil); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("status", resource.Status.Value); err != nil {
-					return err
-				}
-			}
+	if resource.Role == nil {
+		if err = d.Set("role_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("role_id", resource.Role.ID); err != nil {
+			return err
+		}
+	}
 
-			if err = d.Set("tags", resource.Tags); err != nil {
-				return err
-			}
+	if resource.Site == nil {
+		if err = d.Set("site_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("site_id", resource.Site.ID); err != nil {
+			return err
+		}
+	}
 
-			if resource.Tenant == nil {
-				if err = d.Set("tenant_id", nil); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("tenant_id", resource.Tenant.ID); err != nil {
-					return err
-				}
-			}
+	if resource.Status == nil {
+		if err = d.Set("status", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("status", resource.Status.Value); err != nil {
+			return err
+		}
+	}
 
-			if err = d.Set("vlan_id", resource.Vid); err != nil {
-				return err
-			}
+	if err = setResourceTags(d, client, resource.Tags); err != nil {
+		return err
+	}
 
-			return nil
+	if resource.Tenant == nil {
+		if err = d.Set("tenant_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("tenant_id", resource.Tenant.ID); err != nil {
+			return err
 		}
 	}
 
-	d.SetId("")
+	if err = d.Set("vlan_id", resource.Vid); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func resourceNetboxIpamVlanUpdate(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 	params := &models.WritableVLAN{}
 
 	if d.HasChange("description") {
@@ -244,8 +246,11 @@ func resourceNetboxIpamVlanUpdate(d *schema.ResourceData,
 		params.Status = d.Get("status").(string)
 	}
 
-	tags := d.Get("tags").(*schema.Set).List()
-	params.Tags = expandToStringSlice(tags)
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+	params.Tags = tags
 
 	if d.HasChange("tenant_id") {
 		tenantID := int64(d.Get("tenant_id").(int))
@@ -259,17 +264,24 @@ func resourceNetboxIpamVlanUpdate(d *schema.ResourceData,
 		params.Vid = &vid
 	}
 
-	resource := ipam.NewIpamVlansPartialUpdateParams().WithData(
-		params)
+	patch, err := newNullablePatch(params)
+	if err != nil {
+		return err
+	}
+
+	patch.unset(d, "description", "description")
+	patch.unset(d, "vlan_group_id", "group")
+	patch.unset(d, "role_id", "role")
+	patch.unset(d, "site_id", "site")
+	patch.unset(d, "tenant_id", "tenant")
 
 	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return pkgerrors.New("Unable to convert ID into int64")
 	}
 
-	resource.SetID(resourceID)
-
-	_, err = client.Ipam.IpamVlansPartialUpdate(resource, nil)
+	err = netboxPartialUpdate(client, "ipam_vlans_partial_update",
+		"/ipam/vlans/{id}/", resourceID, patch)
 	if err != nil {
 		return err
 	}
@@ -278,48 +290,62 @@ func resourceNetboxIpamVlanUpdate(d *schema.ResourceData,
 }
 
 func resourceNetboxIpamVlanDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
-
-	resourceExists, err := resourceNetboxIpamVlanExists(d, m)
-	if err != nil {
-		return err
-	}
-
-	if !resourceExists {
-		return nil
-	}
+	client := m.(*providerClient)
 
 	id, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return pkgerrors.New("Unable to convert ID into int64")
 	}
 
-	resource := ipam.NewIpamVlansDeleteParams().WithID(id)
-	if _, err := client.Ipam.IpamVlansDelete(resource, nil); err != nil {
-		return err
-	}
+	defer client.cache.forget(cacheIpamVlans, id)
 
-	return nil
-}
+	switch d.Get("deletion_policy").(string) {
+	case deletionPolicyRetain:
+		return nil
+	case deletionPolicyDeprecate:
+		// also used by netbox_ipam_vlan_by_group where the VID is computed
+		vid, ok := d.GetOk("vlan_id")
+		if !ok {
+			vid = d.Get("vid")
+		}
 
-func resourceNetboxIpamVlanExists(d *schema.ResourceData, m interface{}) (b bool,
-	e error) {
-	client := m.(*netboxclient.NetBoxAPI)
-	resourceExist := false
+		name := d.Get("name").(string)
+		vidInt64 := int64(vid.(int))
 
-	vlanID := d.Id()
-	params := ipam.NewIpamVlansListParams().WithID(&vlanID)
-	vlans, err := client.Ipam.IpamVlansList(params, nil)
+		tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+		if err != nil {
+			return err
+		}
 
-	if err != nil {
-		return resourceExist, err
+		params := &models.WritableVLAN{
+			Description: deprecatedDescription(),
+			Name:        &name,
+			Status:      "deprecated",
+			Tags:        tags,
+			Vid:         &vidInt64,
+		}
+
+		resource := ipam.NewIpamVlansPartialUpdateParams().WithData(params)
+		resource.SetID(id)
+
+		_, err = client.Ipam.IpamVlansPartialUpdate(resource, nil)
+		if err != nil {
+			// already deleted outside of terraform
+			if m, _ := regexp.MatchString("status 404", err.Error()); m {
+				return nil
+			}
+		}
+		return err
 	}
 
-	for _, vlan := range vlans.Payload.Results {
-		if strconv.FormatInt(vlan.ID, 10) == d.Id() {
-			resourceExist = true
+	resource := ipam.NewIpamVlansDeleteParams().WithID(id)
+	if _, err := client.Ipam.IpamVlansDelete(resource, nil); err != nil {
+		// already deleted outside of terraform
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			return nil
 		}
+		return err
 	}
 
-	return resourceExist, nil
+	return nil
 }
@@ -0,0 +1,400 @@
+package netbox
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/ipam"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+// Number of times the creation is retried when another client claims the
+// chosen VID in the meantime.
+const vlanByGroupCreateRetries = 5
+
+func resourceNetboxIpamVlanByGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetboxIpamVlanByGroupCreate,
+		Read:   resourceNetboxIpamVlanByGroupRead,
+		Update: resourceNetboxIpamVlanByGroupUpdate,
+		Delete: resourceNetboxIpamVlanDelete,
+
+		CustomizeDiff: resourceNetboxIpamVlanByGroupCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"deletion_policy": deletionPolicySchema(),
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 100),
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 50),
+			},
+			"reserved_vids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(1, 4094),
+				},
+			},
+			"role_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"site_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "active",
+				ValidateFunc: validation.StringInSlice([]string{"active", "reserved",
+					"deprecated"}, false),
+			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
+			"tenant_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"vid": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"vid_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      4094,
+				ValidateFunc: validation.IntBetween(1, 4094),
+			},
+			"vid_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(1, 4094),
+			},
+			"vlan_group_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceNetboxIpamVlanByGroupCreate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	description := d.Get("description").(string)
+	groupID := int64(d.Get("vlan_group_id").(int))
+	name := d.Get("name").(string)
+	roleID := int64(d.Get("role_id").(int))
+	siteID := int64(d.Get("site_id").(int))
+	status := d.Get("status").(string)
+	tenantID := int64(d.Get("tenant_id").(int))
+	vidMin := int64(d.Get("vid_min").(int))
+	vidMax := int64(d.Get("vid_max").(int))
+
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
+	if vidMin > vidMax {
+		return pkgerrors.New("vid_min must be lower than or equal to vid_max")
+	}
+
+	reserved := make(map[int64]bool)
+	for _, vid := range d.Get("reserved_vids").(*schema.Set).List() {
+		reserved[int64(vid.(int))] = true
+	}
+
+	for attempt := 1; ; attempt++ {
+		used, err := resourceNetboxIpamVlanByGroupUsedVids(client, groupID)
+		if err != nil {
+			return err
+		}
+
+		var vid int64
+		for candidate := vidMin; candidate <= vidMax; candidate++ {
+			if !used[candidate] && !reserved[candidate] {
+				vid = candidate
+				break
+			}
+		}
+
+		if vid == 0 {
+			return pkgerrors.New("No free VID between " +
+				strconv.FormatInt(vidMin, 10) + " and " +
+				strconv.FormatInt(vidMax, 10) + " in the vlan group.")
+		}
+
+		newResource := &models.WritableVLAN{
+			Description: description,
+			Group:       &groupID,
+			Name:        &name,
+			Status:      status,
+			Tags:        tags,
+			Vid:         &vid,
+		}
+
+		if roleID != 0 {
+			newResource.Role = &roleID
+		}
+
+		if siteID != 0 {
+			newResource.Site = &siteID
+		}
+
+		if tenantID != 0 {
+			newResource.Tenant = &tenantID
+		}
+
+		// sent without the generated client, whose errors drop the body
+		// telling a VID conflict from the other validation errors
+		var resourceCreated models.VLAN
+		err = netboxSubmit(client, "ipam_vlans_create", "POST", "/ipam/vlans/",
+			0, newResource, &resourceCreated)
+		if err != nil {
+			// somebody else was faster so the lookup is done again
+			if ipamVlanByGroupVidConflict(err) &&
+				attempt < vlanByGroupCreateRetries {
+				continue
+			}
+			return err
+		}
+
+		d.SetId(strconv.FormatInt(resourceCreated.ID, 10))
+		return resourceNetboxIpamVlanByGroupRead(d, m)
+	}
+}
+
+// resourceNetboxIpamVlanByGroupCustomizeDiff replaces the VLAN when its VID
+// no longer fits vid_min, vid_max and reserved_vids. The VLAN is kept when
+// only the constraints change and its VID still fits.
+func resourceNetboxIpamVlanByGroupCustomizeDiff(d *schema.ResourceDiff,
+	m interface{}) error {
+	if err := customizeDiffTagsAll(d, m); err != nil {
+		return err
+	}
+
+	if d.Id() == "" || !d.NewValueKnown("vid_min") ||
+		!d.NewValueKnown("vid_max") || !d.NewValueKnown("reserved_vids") {
+		return nil
+	}
+
+	vid := d.Get("vid").(int)
+	fits := vid >= d.Get("vid_min").(int) && vid <= d.Get("vid_max").(int) &&
+		!d.Get("reserved_vids").(*schema.Set).Contains(vid)
+	if fits {
+		return nil
+	}
+
+	if err := d.SetNewComputed("vid"); err != nil {
+		return err
+	}
+
+	return d.ForceNew("vid")
+}
+
+// ipamVlanByGroupVidConflict tells whether the creation of a VLAN failed
+// because its VID is already used in the group. Netbox enforces unique VIDs
+// within a group and answers with status 400 and the message of its unique
+// together validator: "The fields group, vid must make a unique set.".
+func ipamVlanByGroupVidConflict(err error) bool {
+	e, ok := err.(*netboxError)
+	if !ok || e.code != 400 {
+		return false
+	}
+
+	m, _ := regexp.MatchString(`group, vid must make a unique set`, e.body)
+	return m
+}
+
+// resourceNetboxIpamVlanByGroupUsedVids returns the VIDs already used in a
+// vlan group.
+func resourceNetboxIpamVlanByGroupUsedVids(client *providerClient,
+	groupID int64) (map[int64]bool, error) {
+	used := make(map[int64]bool)
+
+	groupIDStr := strconv.FormatInt(groupID, 10)
+	params := ipam.NewIpamVlansListParams().WithGroupID(&groupIDStr)
+
+	vlans, err := ipamVlansListAll(client, params)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vlan := range vlans {
+		used[*vlan.Vid] = true
+	}
+
+	return used, nil
+}
+
+func resourceNetboxIpamVlanByGroupRead(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	resource, err := ipamVlanGet(client, resourceID)
+	if err != nil {
+		return err
+	}
+
+	if resource == nil {
+		d.SetId("")
+		return nil
+	}
+
+	if err = d.Set("description", resource.Description); err != nil {
+		return err
+	}
+
+	if resource.Group == nil {
+		if err = d.Set("vlan_group_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("vlan_group_id", resource.Group.ID); err != nil {
+			return err
+		}
+	}
+
+	if err = d.Set("name", resource.Name); err != nil {
+		return err
+	}
+
+	if resource.Role == nil {
+		if err = d.Set("role_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("role_id", resource.Role.ID); err != nil {
+			return err
+		}
+	}
+
+	if resource.Site == nil {
+		if err = d.Set("site_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("site_id", resource.Site.ID); err != nil {
+			return err
+		}
+	}
+
+	if resource.Status == nil {
+		if err = d.Set("status", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("status", resource.Status.Value); err != nil {
+			return err
+		}
+	}
+
+	if err = setResourceTags(d, client, resource.Tags); err != nil {
+		return err
+	}
+
+	if resource.Tenant == nil {
+		if err = d.Set("tenant_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("tenant_id", resource.Tenant.ID); err != nil {
+			return err
+		}
+	}
+
+	if err = d.Set("vid", resource.Vid); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceNetboxIpamVlanByGroupUpdate(d *schema.ResourceData,
+	m interface{}) error {
+	client := m.(*providerClient)
+	params := &models.WritableVLAN{}
+
+	if d.HasChange("description") {
+		description := d.Get("description").(string)
+		params.Description = description
+	}
+
+	if d.HasChange("name") {
+		name := d.Get("name").(string)
+		params.Name = &name
+	}
+
+	if d.HasChange("role_id") {
+		roleID := int64(d.Get("role_id").(int))
+		if roleID != 0 {
+			params.Role = &roleID
+		}
+	}
+
+	if d.HasChange("site_id") {
+		siteID := int64(d.Get("site_id").(int))
+		if siteID != 0 {
+			params.Site = &siteID
+		}
+	}
+
+	if d.HasChange("status") {
+		params.Status = d.Get("status").(string)
+	}
+
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+	params.Tags = tags
+
+	if d.HasChange("tenant_id") {
+		tenantID := int64(d.Get("tenant_id").(int))
+		if tenantID != 0 {
+			params.Tenant = &tenantID
+		}
+	}
+
+	patch, err := newNullablePatch(params)
+	if err != nil {
+		return err
+	}
+
+	patch.unset(d, "description", "description")
+	patch.unset(d, "role_id", "role")
+	patch.unset(d, "site_id", "site")
+	patch.unset(d, "tenant_id", "tenant")
+
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	err = netboxPartialUpdate(client, "ipam_vlans_partial_update",
+		"/ipam/vlans/{id}/", resourceID, patch)
+	if err != nil {
+		return err
+	}
+
+	return resourceNetboxIpamVlanByGroupRead(d, m)
+}
@@ -0,0 +1,148 @@
+package netbox
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+func TestIpamVlanByGroupVidConflict(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		err      error
+		conflict bool
+	}{
+		{
+			name: "vid used in the group",
+			err: &netboxError{operation: "ipam_vlans_create", code: 400,
+				body: `{"non_field_errors":["The fields group, vid must ` +
+					`make a unique set."]}`},
+			conflict: true,
+		},
+		{
+			name: "name used in the group",
+			err: &netboxError{operation: "ipam_vlans_create", code: 400,
+				body: `{"non_field_errors":["The fields group, name must ` +
+					`make a unique set."]}`},
+		},
+		{
+			name: "other validation error",
+			err: &netboxError{operation: "ipam_vlans_create", code: 400,
+				body: `{"status":["\"foo\" is not a valid choice."]}`},
+		},
+		{
+			name: "server error",
+			err: &netboxError{operation: "ipam_vlans_create", code: 500,
+				body: "group, vid must make a unique set"},
+		},
+		{
+			name: "not a netbox error",
+			err:  errors.New("group, vid must make a unique set"),
+		},
+	} {
+		if got := ipamVlanByGroupVidConflict(test.err); got != test.conflict {
+			t.Errorf("%s: got %t, want %t", test.name, got, test.conflict)
+		}
+	}
+}
+
+func TestIpamVlanByGroupVidConflictResponse(t *testing.T) {
+	client, done := newTestClient(func(w http.ResponseWriter,
+		r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"non_field_errors":["The fields group, vid ` +
+			`must make a unique set."]}`))
+	})
+	defer done()
+
+	var created models.VLAN
+	err := netboxSubmit(client, "ipam_vlans_create", "POST", "/ipam/vlans/", 0,
+		&models.WritableVLAN{}, &created)
+	if err == nil {
+		t.Fatal("expected an error for the status 400")
+	}
+
+	if !ipamVlanByGroupVidConflict(err) {
+		t.Errorf("the response is not seen as a VID conflict: %s", err)
+	}
+}
+
+func TestIpamVlanByGroupConstraintsChange(t *testing.T) {
+	client := &providerClient{}
+
+	d := schema.TestResourceDataRaw(t, resourceNetboxIpamVlanByGroup().Schema,
+		map[string]interface{}{
+			"name":          "vlan",
+			"vlan_group_id": 1,
+			"vid_min":       1,
+			"vid_max":       4094,
+		})
+	d.SetId("1")
+	if err := d.Set("vid", 10); err != nil {
+		t.Fatal(err)
+	}
+	state := d.State()
+
+	for _, test := range []struct {
+		name        string
+		config      map[string]interface{}
+		requiresNew bool
+	}{
+		{
+			name:   "vid still within the range",
+			config: map[string]interface{}{"vid_min": 5, "vid_max": 10},
+		},
+		{
+			name:        "vid below vid_min",
+			config:      map[string]interface{}{"vid_min": 20},
+			requiresNew: true,
+		},
+		{
+			name:        "vid above vid_max",
+			config:      map[string]interface{}{"vid_max": 9},
+			requiresNew: true,
+		},
+		{
+			name: "other vid reserved",
+			config: map[string]interface{}{
+				"reserved_vids": []interface{}{11, 12},
+			},
+		},
+		{
+			name: "vid reserved",
+			config: map[string]interface{}{
+				"reserved_vids": []interface{}{10},
+			},
+			requiresNew: true,
+		},
+	} {
+		config := map[string]interface{}{
+			"name":          "vlan",
+			"vlan_group_id": 1,
+		}
+		for k, v := range test.config {
+			config[k] = v
+		}
+
+		diff, err := resourceNetboxIpamVlanByGroup().Diff(state,
+			terraform.NewResourceConfigRaw(config), client)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff.Empty() {
+			t.Errorf("%s: expected a diff", test.name)
+			continue
+		}
+
+		if diff.RequiresNew() != test.requiresNew {
+			t.Errorf("%s: got requires new %t, want %t: %v", test.name,
+				diff.RequiresNew(), test.requiresNew, diff)
+		}
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	pkgerrors "github.com/pkg/errors"
-	netboxclient "github.com/tomasherout/go-netbox/netbox/client"
 	"github.com/tomasherout/go-netbox/netbox/client/ipam"
 	"github.com/tomasherout/go-netbox/netbox/models"
 )
@@ -18,7 +17,6 @@ func resourceNetboxIpamVlanGroup() *schema.Resource {
 		Read:   resourceNetboxIpamVlanGroupRead,
 		Update: resourceNetboxIpamVlanGroupUpdate,
 		Delete: resourceNetboxIpamVlanGroupDelete,
-		Exists: resourceNetboxIpamVlanGroupExists,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -43,7 +41,7 @@ func resourceNetboxIpamVlanGroup() *schema.Resource {
 
 func resourceNetboxIpamVlanGroupCreate(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
 	groupName := d.Get("name").(string)
 	groupSiteID := int64(d.Get("site_id").(int))
@@ -71,46 +69,49 @@ func resourceNetboxIpamVlanGroupCreate(d *schema.ResourceData,
 
 func resourceNetboxIpamVlanGroupRead(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
-	resourceID := d.Id()
-	params := ipam.NewIpamVlanGroupsListParams().WithID(&resourceID)
-	resources, err := client.Ipam.IpamVlanGroupsList(params, nil)
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return err
+		return pkgerrors.New("Unable to convert ID into int64")
 	}
 
-	for _, resource := range resources.Payload.Results {
-		if strconv.FormatInt(resource.ID, 10) == d.Id() {
-			if err = d.Set("name", resource.Name); err != nil {
-				return err
-			}
+	params := ipam.NewIpamVlanGroupsReadParams().WithID(resourceID)
+	response, err := client.Ipam.IpamVlanGroupsRead(params, nil)
+	if err != nil {
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
 
-			if resource.Site == nil {
-				if err = d.Set("site_id", nil); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("site_id", resource.Site.ID); err != nil {
-					return err
-				}
-			}
+	resource := response.Payload
 
-			if err = d.Set("slug", resource.Slug); err != nil {
-				return err
-			}
+	if err = d.Set("name", resource.Name); err != nil {
+		return err
+	}
 
-			return nil
+	if resource.Site == nil {
+		if err = d.Set("site_id", nil); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("site_id", resource.Site.ID); err != nil {
+			return err
 		}
 	}
 
-	d.SetId("")
+	if err = d.Set("slug", resource.Slug); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func resourceNetboxIpamVlanGroupUpdate(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 	params := &models.WritableVLANGroup{}
 
 	name := d.Get("name").(string)
@@ -128,17 +129,20 @@ func resourceNetboxIpamVlanGroupUpdate(d *schema.ResourceData,
 		params.Slug = &slug
 	}
 
-	resource := ipam.NewIpamVlanGroupsPartialUpdateParams().WithData(
-		params)
+	patch, err := newNullablePatch(params)
+	if err != nil {
+		return err
+	}
+
+	patch.unset(d, "site_id", "site")
 
 	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return pkgerrors.New("Unable to convert ID into int64")
 	}
 
-	resource.SetID(resourceID)
-
-	_, err = client.Ipam.IpamVlanGroupsPartialUpdate(resource, nil)
+	err = netboxPartialUpdate(client, "ipam_vlan-groups_partial_update",
+		"/ipam/vlan-groups/{id}/", resourceID, patch)
 	if err != nil {
 		return err
 	}
@@ -147,16 +151,7 @@ func resourceNetboxIpamVlanGroupUpdate(d *schema.ResourceData,
 }
 
 func resourceNetboxIpamVlanGroupDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
-
-	resourceExists, err := resourceNetboxIpamVlanGroupExists(d, m)
-	if err != nil {
-		return err
-	}
-
-	if !resourceExists {
-		return nil
-	}
+	client := m.(*providerClient)
 
 	id, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
@@ -165,29 +160,12 @@ func resourceNetboxIpamVlanGroupDelete(d *schema.ResourceData, m interface{}) er
 
 	resource := ipam.NewIpamVlanGroupsDeleteParams().WithID(id)
 	if _, err := client.Ipam.IpamVlanGroupsDelete(resource, nil); err != nil {
+		// already deleted outside of terraform
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			return nil
+		}
 		return err
 	}
 
 	return nil
 }
-
-func resourceNetboxIpamVlanGroupExists(d *schema.ResourceData, m interface{}) (b bool,
-	e error) {
-	client := m.(*netboxclient.NetBoxAPI)
-	resourceExist := false
-
-	resourceID := d.Id()
-	params := ipam.NewIpamVlanGroupsListParams().WithID(&resourceID)
-	resources, err := client.Ipam.IpamVlanGroupsList(params, nil)
-	if err != nil {
-		return resourceExist, err
-	}
-
-	for _, resource := range resources.Payload.Results {
-		if strconv.FormatInt(resource.ID, 10) == d.Id() {
-			resourceExist = true
-		}
-	}
-
-	return resourceExist, nil
-}
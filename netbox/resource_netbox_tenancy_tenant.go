@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	pkgerrors "github.com/pkg/errors"
-	netboxclient "github.com/tomasherout/go-netbox/netbox/client"
 	"github.com/tomasherout/go-netbox/netbox/client/tenancy"
 	"github.com/tomasherout/go-netbox/netbox/models"
 )
@@ -18,7 +17,8 @@ func resourceNetboxTenancyTenant() *schema.Resource {
 		Read:   resourceNetboxTenancyTenantRead,
 		Update: resourceNetboxTenancyTenantUpdate,
 		Delete: resourceNetboxTenancyTenantDelete,
-		Exists: resourceNetboxTenancyTenantExists,
+
+		CustomizeDiff: customizeDiffTagsAll,
 
 		Schema: map[string]*schema.Schema{
 			"comments": {
@@ -48,34 +48,33 @@ func resourceNetboxTenancyTenant() *schema.Resource {
 					regexp.MustCompile("^[-a-zA-Z0-9_]{1,50}$"),
 					"Must be like ^[-a-zA-Z0-9_]{1,50}$"),
 			},
-			"tags": {
-				Type: schema.TypeSet,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-				Optional: true,
-			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
 
 func resourceNetboxTenancyTenantCreate(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
 	comments := d.Get("comments").(string)
 	description := d.Get("description").(string)
 	groupID := int64(d.Get("tenant_group_id").(int))
 	name := d.Get("name").(string)
 	slug := d.Get("slug").(string)
-	tags := d.Get("tags").(*schema.Set).List()
+
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
 
 	newResource := &models.WritableTenant{
 		Comments:    comments,
 		Description: description,
 		Name:        &name,
 		Slug:        &slug,
-		Tags:        expandToStringSlice(tags),
+		Tags:        tags,
 	}
 
 	if groupID != 0 {
@@ -96,58 +95,61 @@ func resourceNetboxTenancyTenantCreate(d *schema.ResourceData,
 
 func resourceNetboxTenancyTenantRead(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
-	resourceID := d.Id()
-	params := tenancy.NewTenancyTenantsListParams().WithID(&resourceID)
-	resources, err := client.Tenancy.TenancyTenantsList(params, nil)
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
+		return pkgerrors.New("Unable to convert ID into int64")
+	}
+
+	params := tenancy.NewTenancyTenantsReadParams().WithID(resourceID)
+	response, err := client.Tenancy.TenancyTenantsRead(params, nil)
+	if err != nil {
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 
-	for _, resource := range resources.Payload.Results {
-		if strconv.FormatInt(resource.ID, 10) == d.Id() {
-			if err = d.Set("comments", resource.Comments); err != nil {
-				return err
-			}
+	resource := response.Payload
 
-			if err = d.Set("description", resource.Description); err != nil {
-				return err
-			}
+	if err = d.Set("comments", resource.Comments); err != nil {
+		return err
+	}
 
-			if resource.Group == nil {
-				if err = d.Set("tenant_group_id", 0); err != nil {
-					return err
-				}
-			} else {
-				if err = d.Set("tenant_group_id", resource.Group.ID); err != nil {
-					return err
-				}
-			}
+	if err = d.Set("description", resource.Description); err != nil {
+		return err
+	}
 
-			if err = d.Set("name", resource.Name); err != nil {
-				return err
-			}
+	if resource.Group == nil {
+		if err = d.Set("tenant_group_id", 0); err != nil {
+			return err
+		}
+	} else {
+		if err = d.Set("tenant_group_id", resource.Group.ID); err != nil {
+			return err
+		}
+	}
 
-			if err = d.Set("slug", resource.Slug); err != nil {
-				return err
-			}
+	if err = d.Set("name", resource.Name); err != nil {
+		return err
+	}
 
-			if err = d.Set("tags", resource.Tags); err != nil {
-				return err
-			}
+	if err = d.Set("slug", resource.Slug); err != nil {
+		return err
+	}
 
-			return nil
-		}
+	if err = setResourceTags(d, client, resource.Tags); err != nil {
+		return err
 	}
 
-	d.SetId("")
 	return nil
 }
 
 func resourceNetboxTenancyTenantUpdate(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 	params := &models.WritableTenant{}
 
 	if d.HasChange("comments") {
@@ -171,19 +173,28 @@ func resourceNetboxTenancyTenantUpdate(d *schema.ResourceData,
 	slug := d.Get("slug").(string)
 	params.Slug = &slug
 
-	tags := d.Get("tags").(*schema.Set).List()
-	params.Tags = expandToStringSlice(tags)
+	tags, err := expandTags(client, d.Get("tags").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+	params.Tags = tags
 
-	resource := tenancy.NewTenancyTenantsPartialUpdateParams().WithData(params)
+	patch, err := newNullablePatch(params)
+	if err != nil {
+		return err
+	}
+
+	patch.unset(d, "comments", "comments")
+	patch.unset(d, "description", "description")
+	patch.unset(d, "tenant_group_id", "group")
 
 	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return pkgerrors.New("Unable to convert ID into int64")
 	}
 
-	resource.SetID(resourceID)
-
-	_, err = client.Tenancy.TenancyTenantsPartialUpdate(resource, nil)
+	err = netboxPartialUpdate(client, "tenancy_tenants_partial_update",
+		"/tenancy/tenants/{id}/", resourceID, patch)
 	if err != nil {
 		return err
 	}
@@ -193,16 +204,7 @@ func resourceNetboxTenancyTenantUpdate(d *schema.ResourceData,
 
 func resourceNetboxTenancyTenantDelete(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
-
-	resourceExists, err := resourceNetboxTenancyTenantExists(d, m)
-	if err != nil {
-		return err
-	}
-
-	if !resourceExists {
-		return nil
-	}
+	client := m.(*providerClient)
 
 	id, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
@@ -211,30 +213,12 @@ func resourceNetboxTenancyTenantDelete(d *schema.ResourceData,
 
 	p := tenancy.NewTenancyTenantsDeleteParams().WithID(id)
 	if _, err := client.Tenancy.TenancyTenantsDelete(p, nil); err != nil {
+		// already deleted outside of terraform
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			return nil
+		}
 		return err
 	}
 
 	return nil
 }
-
-func resourceNetboxTenancyTenantExists(d *schema.ResourceData,
-	m interface{}) (b bool,
-	e error) {
-	client := m.(*netboxclient.NetBoxAPI)
-	resourceExist := false
-
-	resourceID := d.Id()
-	params := tenancy.NewTenancyTenantsListParams().WithID(&resourceID)
-	resources, err := client.Tenancy.TenancyTenantsList(params, nil)
-	if err != nil {
-		return resourceExist, err
-	}
-
-	for _, resource := range resources.Payload.Results {
-		if strconv.FormatInt(resource.ID, 10) == d.Id() {
-			resourceExist = true
-		}
-	}
-
-	return resourceExist, nil
-}
@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	pkgerrors "github.com/pkg/errors"
-	netboxclient "github.com/tomasherout/go-netbox/netbox/client"
 	"github.com/tomasherout/go-netbox/netbox/client/tenancy"
 	"github.com/tomasherout/go-netbox/netbox/models"
 )
@@ -18,7 +17,6 @@ func resourceNetboxTenancyTenantGroup() *schema.Resource {
 		Read:   resourceNetboxTenancyTenantGroupRead,
 		Update: resourceNetboxTenancyTenantGroupUpdate,
 		Delete: resourceNetboxTenancyTenantGroupDelete,
-		Exists: resourceNetboxTenancyTenantGroupExists,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -39,7 +37,7 @@ func resourceNetboxTenancyTenantGroup() *schema.Resource {
 
 func resourceNetboxTenancyTenantGroupCreate(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
 	groupName := d.Get("name").(string)
 	groupSlug := d.Get("slug").(string)
@@ -63,37 +61,39 @@ func resourceNetboxTenancyTenantGroupCreate(d *schema.ResourceData,
 
 func resourceNetboxTenancyTenantGroupRead(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 
-	resourceID := d.Id()
-	params := tenancy.NewTenancyTenantGroupsListParams().WithID(&resourceID)
-	resources, err := client.Tenancy.TenancyTenantGroupsList(params, nil)
+	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return err
+		return pkgerrors.New("Unable to convert ID into int64")
 	}
 
-	for _, resource := range resources.Payload.Results {
-		if strconv.FormatInt(resource.ID, 10) == d.Id() {
-			if err = d.Set("name", resource.Name); err != nil {
-				return err
-			}
-
-			if err = d.Set("slug", resource.Slug); err != nil {
-				return err
-			}
-
+	params := tenancy.NewTenancyTenantGroupsReadParams().WithID(resourceID)
+	response, err := client.Tenancy.TenancyTenantGroupsRead(params, nil)
+	if err != nil {
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			d.SetId("")
 			return nil
 		}
+		return err
 	}
 
-	d.SetId("")
+	resource := response.Payload
+
+	if err = d.Set("name", resource.Name); err != nil {
+		return err
+	}
+
+	if err = d.Set("slug", resource.Slug); err != nil {
+		return err
+	}
 
 	return nil
 }
 
 func resourceNetboxTenancyTenantGroupUpdate(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
+	client := m.(*providerClient)
 	params := &models.WritableTenantGroup{}
 
 	if d.HasChange("name") {
@@ -106,17 +106,18 @@ func resourceNetboxTenancyTenantGroupUpdate(d *schema.ResourceData,
 		params.Slug = &slug
 	}
 
-	resource := tenancy.NewTenancyTenantGroupsPartialUpdateParams().WithData(
-		params)
+	patch, err := newNullablePatch(params)
+	if err != nil {
+		return err
+	}
 
 	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return pkgerrors.New("Unable to convert ID into int64")
 	}
 
-	resource.SetID(resourceID)
-
-	_, err = client.Tenancy.TenancyTenantGroupsPartialUpdate(resource, nil)
+	err = netboxPartialUpdate(client, "tenancy_tenant-groups_partial_update",
+		"/tenancy/tenant-groups/{id}/", resourceID, patch)
 	if err != nil {
 		return err
 	}
@@ -126,16 +127,7 @@ func resourceNetboxTenancyTenantGroupUpdate(d *schema.ResourceData,
 
 func resourceNetboxTenancyTenantGroupDelete(d *schema.ResourceData,
 	m interface{}) error {
-	client := m.(*netboxclient.NetBoxAPI)
-
-	resourceExists, err := resourceNetboxTenancyTenantGroupExists(d, m)
-	if err != nil {
-		return err
-	}
-
-	if !resourceExists {
-		return nil
-	}
+	client := m.(*providerClient)
 
 	resourceID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
@@ -144,30 +136,12 @@ func resourceNetboxTenancyTenantGroupDelete(d *schema.ResourceData,
 
 	resource := tenancy.NewTenancyTenantGroupsDeleteParams().WithID(resourceID)
 	if _, err := client.Tenancy.TenancyTenantGroupsDelete(resource, nil); err != nil {
+		// already deleted outside of terraform
+		if m, _ := regexp.MatchString("status 404", err.Error()); m {
+			return nil
+		}
 		return err
 	}
 
 	return nil
 }
-
-func resourceNetboxTenancyTenantGroupExists(d *schema.ResourceData,
-	m interface{}) (b bool,
-	e error) {
-	client := m.(*netboxclient.NetBoxAPI)
-	resourceExist := false
-
-	resourceID := d.Id()
-	params := tenancy.NewTenancyTenantGroupsListParams().WithID(&resourceID)
-	resources, err := client.Tenancy.TenancyTenantGroupsList(params, nil)
-	if err != nil {
-		return resourceExist, err
-	}
-
-	for _, resource := range resources.Payload.Results {
-		if strconv.FormatInt(resource.ID, 10) == d.Id() {
-			resourceExist = true
-		}
-	}
-
-	return resourceExist, nil
-}
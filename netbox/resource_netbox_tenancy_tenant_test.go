@@ -0,0 +1,94 @@
+package netbox
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+// testAccTenancyTenantConfig returns a tenant tagged with tags, the tags and
+// the default tags of the provider being created beforehand.
+func testAccTenancyTenantConfig(suffix string, defaultTags string,
+	tags string) string {
+	return fmt.Sprintf(`
+provider "netbox" {
+  default_tags = [%[2]s]
+}
+
+resource "netbox_extras_tag" "alpha" {
+  name = "tf-acc-alpha-%[1]s"
+  slug = "tf-acc-alpha-%[1]s"
+}
+
+resource "netbox_extras_tag" "beta" {
+  name = "tf-acc-beta-%[1]s"
+  slug = "tf-acc-beta-%[1]s"
+}
+
+resource "netbox_extras_tag" "gamma" {
+  name = "tf-acc-gamma-%[1]s"
+  slug = "tf-acc-gamma-%[1]s"
+}
+
+resource "netbox_tenancy_tenant" "test" {
+  name = "tf-acc-%[1]s"
+  slug = "tf-acc-%[1]s"
+  tags = [%[3]s]
+
+  depends_on = [
+    netbox_extras_tag.alpha,
+    netbox_extras_tag.beta,
+    netbox_extras_tag.gamma,
+  ]
+}
+`, suffix, defaultTags, tags)
+}
+
+func TestAccNetboxTenancyTenantTags(t *testing.T) {
+	suffix := acctest.RandString(8)
+	alpha := `"tf-acc-alpha-` + suffix + `"`
+	beta := `"tf-acc-beta-` + suffix + `"`
+	gamma := `"tf-acc-gamma-` + suffix + `"`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTenancyTenantConfig(suffix, "",
+					alpha+", "+beta),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"netbox_tenancy_tenant.test", "tags.#", "2"),
+					resource.TestCheckResourceAttr(
+						"netbox_tenancy_tenant.test", "tags_all.#", "2"),
+				),
+			},
+			// neither the order nor the case of the tags changes the plan
+			{
+				Config: testAccTenancyTenantConfig(suffix, "",
+					strings.ToUpper(beta)+", "+alpha),
+				PlanOnly: true,
+			},
+			// default tags only show in tags_all
+			{
+				Config: testAccTenancyTenantConfig(suffix, gamma,
+					alpha+", "+beta),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"netbox_tenancy_tenant.test", "tags.#", "2"),
+					resource.TestCheckResourceAttr(
+						"netbox_tenancy_tenant.test", "tags_all.#", "3"),
+				),
+			},
+			{
+				Config: testAccTenancyTenantConfig(suffix,
+					strings.ToUpper(gamma), beta+", "+strings.ToUpper(alpha)),
+				PlanOnly: true,
+			},
+		},
+	})
+}
@@ -1,6 +1,13 @@
 package netbox
 
-import "github.com/tomasherout/go-netbox/netbox/models"
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/tomasherout/go-netbox/netbox/client/extras"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
 
 func expandToStringSlice(v []interface{}) []*models.NestedTag {
 	nestedTags := make([]*models.NestedTag, len(v))
@@ -15,6 +22,180 @@ func expandToStringSlice(v []interface{}) []*models.NestedTag {
 	return nestedTags
 }
 
+// expandTags returns the tags of a resource completed by the default tags of
+// the provider, as sent to Netbox. Slugs are matched ignoring the case since
+// Netbox only accepts the exact slug, and an error names the tags which do
+// not exist in Netbox, which otherwise only answers that the object is
+// invalid.
+func expandTags(client *providerClient,
+	tags []interface{}) ([]*models.NestedTag, error) {
+	var missing []string
+	var slugs []interface{}
+
+	for _, tag := range mergeDefaultTags(client, tags) {
+		slug := tag.(string)
+		params := extras.NewExtrasTagsListParams().WithSlugIe(&slug)
+
+		list, err := extrasTagsListAll(client, params)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(list) == 0 {
+			missing = append(missing, slug)
+			continue
+		}
+
+		// an exact match wins when several tags only differ by their case
+		found := *list[0].Slug
+		for _, netboxTag := range list {
+			if *netboxTag.Slug == slug {
+				found = slug
+			}
+		}
+		slugs = append(slugs, found)
+	}
+
+	if len(missing) != 0 {
+		return nil, pkgerrors.New("The tags " + strings.Join(missing, ", ") +
+			" do not exist in Netbox, they can be created with the " +
+			"netbox_extras_tag resource.")
+	}
+
+	return expandToStringSlice(slugs), nil
+}
+
+// mergeDefaultTags returns the tags of a resource completed by the default
+// tags of the provider.
+func mergeDefaultTags(client *providerClient,
+	tags []interface{}) []interface{} {
+	merged := make([]interface{}, 0, len(tags)+len(client.defaultTags))
+	merged = append(merged, tags...)
+
+	for _, defaultTag := range client.defaultTags {
+		if findTag(tags, defaultTag) == "" {
+			merged = append(merged, defaultTag)
+		}
+	}
+
+	return merged
+}
+
+// findTag returns the spelling of slug in tags ignoring the case, or an
+// empty string when tags does not contain it.
+func findTag(tags []interface{}, slug string) string {
+	for _, tag := range tags {
+		if strings.EqualFold(tag.(string), slug) {
+			return tag.(string)
+		}
+	}
+
+	return ""
+}
+
+func isDefaultTag(client *providerClient, slug string) bool {
+	for _, defaultTag := range client.defaultTags {
+		if strings.EqualFold(defaultTag, slug) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hashTag hashes a slug ignoring its case, slugs which only differ by their
+// case are the same tag of a resource.
+func hashTag(v interface{}) int {
+	return schema.HashString(strings.ToLower(v.(string)))
+}
+
+// tagsSchema returns the tags of a resource, neither their order nor their
+// case show up in the diff.
+func tagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeSet,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		Set:      hashTag,
+		Optional: true,
+		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+			return strings.EqualFold(old, new)
+		},
+	}
+}
+
+func tagsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		Set: hashTag,
+	}
+}
+
+// setResourceTags sets tags_all to all the tags of an object and tags to
+// the same tags without the default tags of the provider which are not
+// configured on the resource, so the default tags never show in the diff.
+// The slugs keep the spelling of the configuration when they only differ by
+// their case.
+func setResourceTags(d *schema.ResourceData, client *providerClient,
+	tags []*models.NestedTag) error {
+	configured := d.Get("tags").(*schema.Set).List()
+	merged := mergeDefaultTags(client, configured)
+
+	all := make([]string, 0, len(tags))
+	own := make([]string, 0, len(tags))
+	for _, slug := range flattenTags(tags) {
+		if spelling := findTag(merged, slug); spelling != "" {
+			slug = spelling
+		}
+		all = append(all, slug)
+
+		if findTag(configured, slug) != "" || !isDefaultTag(client, slug) {
+			own = append(own, slug)
+		}
+	}
+
+	if err := d.Set("tags", own); err != nil {
+		return err
+	}
+
+	return d.Set("tags_all", all)
+}
+
+// customizeDiffTagsAll plans tags_all from the configured and the default
+// tags, a change of the default tags of the provider then updates the
+// resources.
+func customizeDiffTagsAll(d *schema.ResourceDiff, m interface{}) error {
+	client := m.(*providerClient)
+
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	merged := mergeDefaultTags(client, d.Get("tags").(*schema.Set).List())
+	if d.Get("tags_all").(*schema.Set).Equal(
+		schema.NewSet(hashTag, merged)) {
+		return nil
+	}
+
+	return d.SetNew("tags_all", merged)
+}
+
+func flattenTags(tags []*models.NestedTag) []string {
+	slugs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag != nil && tag.Slug != nil {
+			slugs = append(slugs, *tag.Slug)
+		}
+	}
+
+	return slugs
+}
+
 /*
  * func diffSlices(oldSlice []string, newSlice []string) []string {
  *   var diff []string
@@ -0,0 +1,260 @@
+package netbox
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/tomasherout/go-netbox/netbox/models"
+)
+
+// netboxTestTags answers the tag list of a fake Netbox filtered by slug__ie.
+func netboxTestTags(slugs ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filter := r.URL.Query().Get("slug__ie")
+
+		results := make([]map[string]interface{}, 0)
+		for i, slug := range slugs {
+			if filter == "" || strings.EqualFold(filter, slug) {
+				results = append(results, map[string]interface{}{
+					"id":    i + 1,
+					"name":  slug,
+					"slug":  slug,
+					"color": "9e9e9e",
+				})
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"count":   len(results),
+			"next":    nil,
+			"results": results,
+		})
+	}
+}
+
+func testNestedTags(slugs ...string) []*models.NestedTag {
+	tags := make([]interface{}, len(slugs))
+	for i, slug := range slugs {
+		tags[i] = slug
+	}
+
+	return expandToStringSlice(tags)
+}
+
+func testSetStrings(t *testing.T, d *schema.ResourceData, key string) []string {
+	t.Helper()
+
+	var values []string
+	for _, v := range d.Get(key).(*schema.Set).List() {
+		values = append(values, v.(string))
+	}
+	sort.Strings(values)
+
+	return values
+}
+
+func testEqualStrings(t *testing.T, name string, got []string,
+	want []string) {
+	t.Helper()
+
+	sort.Strings(got)
+	sort.Strings(want)
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("%s: got %v, want %v", name, got, want)
+	}
+}
+
+func TestExpandTags(t *testing.T) {
+	client, done := newTestClient(netboxTestTags("alpha", "Beta", "env-prod",
+		"Gamma", "gamma"))
+	defer done()
+	client.defaultTags = []string{"ENV-PROD"}
+
+	tags, err := expandTags(client, []interface{}{"ALPHA", "beta", "gamma"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Netbox spelling, the exact match among tags differing by their case
+	testEqualStrings(t, "slugs", flattenTags(tags), []string{"alpha", "Beta",
+		"gamma", "env-prod"})
+}
+
+func TestExpandTagsMissing(t *testing.T) {
+	client, done := newTestClient(netboxTestTags("alpha"))
+	defer done()
+
+	_, err := expandTags(client, []interface{}{"alpha", "delta", "omega"})
+	if err == nil {
+		t.Fatal("expected an error for the missing tags")
+	}
+
+	if !strings.Contains(err.Error(), "delta, omega") {
+		t.Errorf("the error does not name the missing tags: %s", err)
+	}
+}
+
+func TestExpandTagsDefaultTagConfigured(t *testing.T) {
+	client, done := newTestClient(netboxTestTags("env-prod"))
+	defer done()
+	client.defaultTags = []string{"env-prod"}
+
+	tags, err := expandTags(client, []interface{}{"Env-Prod"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// configured and default only once
+	testEqualStrings(t, "slugs", flattenTags(tags), []string{"env-prod"})
+}
+
+func TestSetResourceTagsKeepsConfiguredSpelling(t *testing.T) {
+	client := &providerClient{defaultTags: []string{"Env-Prod"}}
+
+	d := schema.TestResourceDataRaw(t, resourceNetboxTenancyTenant().Schema,
+		map[string]interface{}{
+			"name": "tenant",
+			"slug": "tenant",
+			"tags": []interface{}{"Alpha", "beta"},
+		})
+
+	err := setResourceTags(d, client, testNestedTags("beta", "env-prod",
+		"alpha"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqualStrings(t, "tags", testSetStrings(t, d, "tags"),
+		[]string{"Alpha", "beta"})
+	testEqualStrings(t, "tags_all", testSetStrings(t, d, "tags_all"),
+		[]string{"Alpha", "beta", "Env-Prod"})
+}
+
+func TestSetResourceTagsDefaultTagConfigured(t *testing.T) {
+	client := &providerClient{defaultTags: []string{"env-prod"}}
+
+	d := schema.TestResourceDataRaw(t, resourceNetboxTenancyTenant().Schema,
+		map[string]interface{}{
+			"name": "tenant",
+			"slug": "tenant",
+			"tags": []interface{}{"ENV-PROD"},
+		})
+
+	if err := setResourceTags(d, client, testNestedTags("env-prod")); err != nil {
+		t.Fatal(err)
+	}
+
+	testEqualStrings(t, "tags", testSetStrings(t, d, "tags"),
+		[]string{"ENV-PROD"})
+	testEqualStrings(t, "tags_all", testSetStrings(t, d, "tags_all"),
+		[]string{"ENV-PROD"})
+}
+
+func TestSetResourceTagsAddedOutsideOfTerraform(t *testing.T) {
+	client := &providerClient{}
+
+	d := schema.TestResourceDataRaw(t, resourceNetboxTenancyTenant().Schema,
+		map[string]interface{}{
+			"name": "tenant",
+			"slug": "tenant",
+			"tags": []interface{}{"alpha"},
+		})
+
+	err := setResourceTags(d, client, testNestedTags("alpha", "manual"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// shows as a diff to remove it
+	testEqualStrings(t, "tags", testSetStrings(t, d, "tags"),
+		[]string{"alpha", "manual"})
+}
+
+// testTenantState returns the state of a tenant applied with tags and read
+// back with the tags answered by Netbox.
+func testTenantState(t *testing.T, client *providerClient, tags []interface{},
+	netboxTags []*models.NestedTag) *terraform.InstanceState {
+	t.Helper()
+
+	d := schema.TestResourceDataRaw(t, resourceNetboxTenancyTenant().Schema,
+		map[string]interface{}{
+			"name": "tenant",
+			"slug": "tenant",
+			"tags": tags,
+		})
+	d.SetId("1")
+
+	if err := setResourceTags(d, client, netboxTags); err != nil {
+		t.Fatal(err)
+	}
+
+	return d.State()
+}
+
+func testTenantDiff(t *testing.T, client *providerClient,
+	state *terraform.InstanceState,
+	tags []interface{}) *terraform.InstanceDiff {
+	t.Helper()
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "tenant",
+		"slug": "tenant",
+		"tags": tags,
+	})
+
+	diff, err := resourceNetboxTenancyTenant().Diff(state, config, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return diff
+}
+
+func TestTagsNoOpPlan(t *testing.T) {
+	client := &providerClient{defaultTags: []string{"env-prod"}}
+
+	state := testTenantState(t, client, []interface{}{"Alpha", "beta"},
+		testNestedTags("alpha", "beta", "env-prod"))
+
+	for _, tags := range [][]interface{}{
+		{"Alpha", "beta"},
+		{"beta", "Alpha"},
+		{"BETA", "alpha"},
+	} {
+		if diff := testTenantDiff(t, client, state, tags); !diff.Empty() {
+			t.Errorf("tags %v: expected no diff, got %v", tags, diff)
+		}
+	}
+}
+
+func TestTagsPlanChanges(t *testing.T) {
+	client := &providerClient{defaultTags: []string{"env-prod"}}
+
+	state := testTenantState(t, client, []interface{}{"alpha"},
+		testNestedTags("alpha", "env-prod"))
+
+	diff := testTenantDiff(t, client, state, []interface{}{"alpha", "beta"})
+	if diff.Empty() {
+		t.Error("expected a diff when a tag is added")
+	}
+
+	// a new default tag updates tags_all only
+	client.defaultTags = []string{"env-prod", "team-net"}
+
+	diff = testTenantDiff(t, client, state, []interface{}{"alpha"})
+	if diff.Empty() {
+		t.Fatal("expected a diff when a default tag is added")
+	}
+
+	for k := range diff.Attributes {
+		if strings.HasPrefix(k, "tags.") {
+			t.Errorf("unexpected diff of %s", k)
+		}
+	}
+}